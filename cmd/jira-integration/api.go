@@ -0,0 +1,74 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"jira-integration/internal/api"
+	"jira-integration/pkg/logging"
+	"net/http"
+	"time"
+)
+
+type (
+	apiDatabase interface {
+		api.Database
+		Ping(ctx context.Context) error
+	}
+)
+
+func runAPI(ctx context.Context, app *App, args []string) error {
+	flags := newFlagSet("api", "api [-address host:port]")
+	address := flags.String("address", app.Profile.API.Address, "address to listen on")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+
+	db, err := app.Database(ctx)
+	if err != nil {
+		return err
+	}
+
+	server := &http.Server{
+		Addr:              *address,
+		Handler:           newAPIHandler(db, app.Metrics.Handler()),
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+
+	serverErr := make(chan error, 1)
+	go func() {
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			serverErr <- err
+		}
+	}()
+
+	logging.FromContext(ctx).Info("serving the API", "address", *address)
+	select {
+	case <-ctx.Done():
+		err = nil
+	case err = <-serverErr:
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	_ = server.Shutdown(shutdownCtx)
+
+	return err
+}
+
+// newAPIHandler serves the API with the health check and the Prometheus
+// metrics next to it.
+func newAPIHandler(db apiDatabase, prometheus http.Handler) http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle("/", api.NewServer(db))
+	mux.Handle("GET "+prometheusPath, prometheus)
+	mux.HandleFunc("GET /health", func(w http.ResponseWriter, r *http.Request) {
+		if err := db.Ping(r.Context()); err != nil {
+			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	})
+
+	return mux
+}
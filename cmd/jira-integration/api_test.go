@@ -0,0 +1,51 @@
+package main
+
+import (
+	"context"
+	"jira-integration/internal/api"
+	"jira-integration/internal/database"
+	"jira-integration/pkg/analytics"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type (
+	metricsDatabase struct {
+		api.Database
+	}
+)
+
+func (m metricsDatabase) ListMetrics(context.Context, database.IssueFilter, database.Page) ([]analytics.WorkItem, error) {
+	return []analytics.WorkItem{{IssueID: 1, Key: "PAY-1"}}, nil
+}
+
+func (m metricsDatabase) Ping(context.Context) error {
+	return nil
+}
+
+func TestNewAPIHandler(t *testing.T) {
+	prometheus := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("# prometheus"))
+	})
+	handler := newAPIHandler(metricsDatabase{}, prometheus)
+
+	tests := []struct {
+		path string
+		want string
+	}{
+		{path: "/metrics", want: `"key":"PAY-1"`},
+		{path: prometheusPath, want: "# prometheus"},
+		{path: "/health", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			response := httptest.NewRecorder()
+			handler.ServeHTTP(response, httptest.NewRequest(http.MethodGet, tt.path, nil))
+			if response.Code != http.StatusOK || !strings.Contains(response.Body.String(), tt.want) {
+				t.Errorf("GET %s = %d %s, want %s", tt.path, response.Code, response.Body.String(), tt.want)
+			}
+		})
+	}
+}
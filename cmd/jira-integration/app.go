@@ -0,0 +1,158 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"jira-integration/internal/config"
+	"jira-integration/internal/database"
+	"jira-integration/internal/jira"
+	"jira-integration/internal/telemetry"
+	"jira-integration/pkg/analytics"
+	"net/http"
+	"time"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+const (
+	pushTimeout = 10 * time.Second
+
+	// prometheusPath serves the metrics of the daemon and the API, apart from
+	// the API's own /metrics listing.
+	prometheusPath = "/prometheus"
+)
+
+type (
+	App struct {
+		Profile config.Profile
+		Metrics *telemetry.Metrics
+		conn    *gorm.DB
+		db      *database.Gorm
+		client  *jira.Client
+	}
+)
+
+func NewApp(profile config.Profile) *App {
+	return &App{
+		Profile: profile,
+		Metrics: telemetry.NewMetrics(),
+	}
+}
+
+func (a *App) Database(ctx context.Context) (*database.Gorm, error) {
+	if a.db != nil {
+		return a.db, nil
+	}
+
+	db, err := a.open()
+	if err != nil {
+		return nil, err
+	}
+
+	if *a.Profile.Database.AutoMigrate {
+		if err := db.Migrate(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	a.db = db
+	return db, nil
+}
+
+func (a *App) open() (*database.Gorm, error) {
+	if err := a.Profile.ValidateDatabase(); err != nil {
+		return nil, err
+	}
+
+	if a.conn == nil {
+		conn, err := gorm.Open(postgres.Open(a.Profile.Database.DSN), &gorm.Config{
+			Logger: database.NewLogger(),
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		if err := conn.Use(a.Metrics.GormPlugin()); err != nil {
+			return nil, err
+		}
+
+		a.conn = conn
+	}
+
+	return database.NewGorm(a.conn), nil
+}
+
+func (a *App) Jira(ctx context.Context) (*jira.Client, error) {
+	if a.client != nil {
+		return a.client, nil
+	}
+
+	if err := a.Profile.ValidateJira(); err != nil {
+		return nil, err
+	}
+
+	client := jira.NewClient(a.Profile.Jira.URL, jira.Credentials{
+		Username: a.Profile.Jira.Username,
+		Password: a.Profile.Jira.Password,
+	}, http.DefaultClient).WithObserver(a.Metrics)
+
+	if a.Profile.Jira.Archive {
+		db, err := a.Database(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		client = client.WithArchive(db)
+	}
+
+	a.client = client
+	return a.client, nil
+}
+
+func (a *App) Calculator() (*analytics.Calculator, error) {
+	analyticsConfig := analytics.DefaultConfig()
+	c := a.Profile.Analytics.Calendar
+	calendar, err := analytics.NewCalendar(c.Timezone, c.WorkdayStart, c.WorkdayEnd, c.Weekdays, c.Holidays)
+	if err != nil {
+		return nil, fmt.Errorf("%w: analytics calendar: %v", config.InvalidConfigErr, err)
+	}
+
+	analyticsConfig.Calendar = calendar
+	start, done, active := a.Profile.Analytics.Start, a.Profile.Analytics.Done, a.Profile.Analytics.Active
+	if len(start.Statuses) != 0 || len(start.Categories) != 0 {
+		analyticsConfig.Start = analytics.StatusSet{Names: start.Statuses, Categories: start.Categories}
+	}
+
+	if len(done.Statuses) != 0 || len(done.Categories) != 0 {
+		analyticsConfig.Done = analytics.StatusSet{Names: done.Statuses, Categories: done.Categories}
+	}
+
+	if len(active.Statuses) != 0 || len(active.Categories) != 0 {
+		analyticsConfig.Active = analytics.StatusSet{Names: active.Statuses, Categories: active.Categories}
+	}
+
+	return analytics.NewCalculator(analyticsConfig), nil
+}
+
+// PushMetrics pushes the metrics of a one-shot command when a Pushgateway is
+// configured, giving up after a few seconds.
+func (a *App) PushMetrics(command string) error {
+	if a.Profile.Prometheus.PushURL == "" {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), pushTimeout)
+	defer cancel()
+	return a.Metrics.Push(ctx, a.Profile.Prometheus.PushURL, command)
+}
+
+func (a *App) Close() {
+	if a.conn == nil {
+		return
+	}
+
+	if conn, err := a.conn.DB(); err == nil {
+		_ = conn.Close()
+	}
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"jira-integration/internal/scheduler"
+	"jira-integration/pkg/logging"
+	"jira-integration/usecase"
+	"net/http"
+	"time"
+)
+
+func runDaemon(ctx context.Context, app *App, args []string) error {
+	flags := newFlagSet("daemon", "daemon")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+
+	daemon := app.Profile.Daemon
+	if err := daemon.Validate(); err != nil {
+		return err
+	}
+
+	client, err := app.Jira(ctx)
+	if err != nil {
+		return err
+	}
+
+	db, err := app.Database(ctx)
+	if err != nil {
+		return err
+	}
+
+	calculator, err := app.Calculator()
+	if err != nil {
+		return err
+	}
+
+	stats, err := db.GetStats(ctx)
+	if err != nil {
+		return err
+	}
+
+	app.Metrics.SetWatermark(stats.LastUpdatedAt)
+	metrics := usecase.NewRefreshMetricsUseCase(calculator, db)
+	s := scheduler.NewScheduler(db)
+	for _, jobConfig := range daemon.Jobs {
+		task := newTask(jobConfig, client, db, metrics, app.Metrics.ObserveIssue)
+		if err := s.Add(ctx, scheduler.Job{
+			Name:     jobConfig.Name,
+			Schedule: jobConfig.Schedule,
+			Task:     observeTask(jobConfig.Name, task, app.Metrics),
+		}); err != nil {
+			return err
+		}
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("GET /health", s.HealthHandler(db))
+	mux.Handle("GET "+prometheusPath, app.Metrics.Handler())
+	server := &http.Server{
+		Addr:              daemon.HealthAddress,
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+
+	serverErr := make(chan error, 1)
+	go func() {
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			serverErr <- err
+		}
+	}()
+
+	logging.FromContext(ctx).Info("starting scheduler", "jobs", len(daemon.Jobs), "address", daemon.HealthAddress)
+	s.Start()
+
+	select {
+	case <-ctx.Done():
+		err = nil
+	case err = <-serverErr:
+	}
+
+	logging.FromContext(ctx).Info("shutting down, waiting for running jobs")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	_ = server.Shutdown(shutdownCtx)
+	s.Stop()
+
+	return err
+}
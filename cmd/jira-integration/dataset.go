@@ -0,0 +1,398 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"jira-integration/internal/database"
+	"jira-integration/internal/export"
+	"jira-integration/pkg/analytics"
+	"jira-integration/pkg/issue"
+	"strings"
+	"time"
+)
+
+const (
+	defaultChunkSize = 1000
+)
+
+var (
+	issueColumns = []export.Column{
+		{Name: "id", Type: export.IntColumn},
+		{Name: "key"},
+		{Name: "summary"},
+		{Name: "status"},
+		{Name: "status_id"},
+		{Name: "status_category"},
+		{Name: "issue_type"},
+		{Name: "project"},
+		{Name: "parent_key"},
+		{Name: "sprint_id", Type: export.IntColumn},
+		{Name: "sprint_name"},
+		{Name: "labels"},
+		{Name: "assignee_id"},
+		{Name: "assignee"},
+		{Name: "reporter_id"},
+		{Name: "reporter"},
+		{Name: "story_points", Type: export.IntColumn},
+		{Name: "flagged", Type: export.BoolColumn},
+		{Name: "products"},
+		{Name: "fix_version"},
+		{Name: "locality"},
+		{Name: "created_at", Type: export.TimeColumn},
+		{Name: "updated_at", Type: export.TimeColumn},
+	}
+
+	changelogColumns = []export.Column{
+		{Name: "issue_id", Type: export.IntColumn},
+		{Name: "issue_key"},
+		{Name: "id", Type: export.IntColumn},
+		{Name: "field"},
+		{Name: "from"},
+		{Name: "to"},
+		{Name: "from_id"},
+		{Name: "to_id"},
+		{Name: "from_category"},
+		{Name: "to_category"},
+		{Name: "created_at", Type: export.TimeColumn},
+	}
+
+	sprintColumns = []export.Column{
+		{Name: "id", Type: export.IntColumn},
+		{Name: "name"},
+		{Name: "state"},
+		{Name: "goal"},
+		{Name: "start_date", Type: export.TimeColumn},
+		{Name: "end_date", Type: export.TimeColumn},
+		{Name: "complete_date", Type: export.TimeColumn},
+		{Name: "board_id", Type: export.IntColumn},
+		{Name: "board_name"},
+	}
+
+	metricColumns = []export.Column{
+		{Name: "issue_id", Type: export.IntColumn},
+		{Name: "key"},
+		{Name: "summary"},
+		{Name: "project"},
+		{Name: "issue_type"},
+		{Name: "status"},
+		{Name: "status_category"},
+		{Name: "assignee"},
+		{Name: "story_points", Type: export.IntColumn},
+		{Name: "parent_key"},
+		{Name: "sprint_id", Type: export.IntColumn},
+		{Name: "sprint_name"},
+		{Name: "started_at", Type: export.TimeColumn},
+		{Name: "done_at", Type: export.TimeColumn},
+		{Name: "cycle_time_hours", Type: export.FloatColumn},
+		{Name: "active_hours", Type: export.FloatColumn},
+		{Name: "waiting_hours", Type: export.FloatColumn},
+		{Name: "blocked_hours", Type: export.FloatColumn},
+		{Name: "flow_efficiency", Type: export.FloatColumn},
+	}
+)
+
+type (
+	// datasetFlags are shared by the export commands that stream a table of
+	// the stored issues in chunks.
+	datasetFlags struct {
+		format      *string
+		output      *string
+		chunk       *int
+		projects    *string
+		issueTypes  *string
+		statuses    *string
+		sprints     *string
+		updatedFrom *string
+		updatedTo   *string
+	}
+)
+
+func newDatasetFlags(name string) (*flag.FlagSet, datasetFlags) {
+	flags := newFlagSet("export "+name, "export "+name+" [-format csv|ndjson|parquet] [-output file] [-chunk size] [filters]")
+	return flags, datasetFlags{
+		format:      flags.String("format", export.FormatCSV, "output format: csv, ndjson or parquet"),
+		output:      flags.String("output", "", "output file, defaults to stdout"),
+		chunk:       flags.Int("chunk", defaultChunkSize, "issues read from the database at a time"),
+		projects:    flags.String("projects", "", "comma separated project names"),
+		issueTypes:  flags.String("types", "", "comma separated issue types"),
+		statuses:    flags.String("statuses", "", "comma separated statuses"),
+		sprints:     flags.String("sprints", "", "comma separated sprint ids"),
+		updatedFrom: flags.String("updated-from", "", "issues updated since this day, as YYYY-MM-DD"),
+		updatedTo:   flags.String("updated-to", "", "issues updated before this day, as YYYY-MM-DD"),
+	}
+}
+
+func (f datasetFlags) filter() (database.IssueFilter, error) {
+	if err := validateStreamFormat(*f.format); err != nil {
+		return database.IssueFilter{}, err
+	}
+
+	if *f.chunk <= 0 {
+		return database.IssueFilter{}, fmt.Errorf("%w: -chunk must be positive", UsageErr)
+	}
+
+	sprints, err := parseUintList(*f.sprints)
+	if err != nil {
+		return database.IssueFilter{}, err
+	}
+
+	updatedFrom, err := parseDate(*f.updatedFrom, time.Local, time.Time{})
+	if err != nil {
+		return database.IssueFilter{}, err
+	}
+
+	updatedTo, err := parseDate(*f.updatedTo, time.Local, time.Time{})
+	if err != nil {
+		return database.IssueFilter{}, err
+	}
+
+	return database.IssueFilter{
+		Projects:    splitList(*f.projects),
+		IssueTypes:  splitList(*f.issueTypes),
+		Statuses:    splitList(*f.statuses),
+		Sprints:     sprints,
+		UpdatedFrom: updatedFrom,
+		UpdatedTo:   updatedTo,
+	}, nil
+}
+
+func runExportIssues(ctx context.Context, app *App, args []string) error {
+	flags, dataset := newDatasetFlags("issues")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+
+	filter, err := dataset.filter()
+	if err != nil {
+		return err
+	}
+
+	db, err := app.Database(ctx)
+	if err != nil {
+		return err
+	}
+
+	return exportChunks(ctx, dataset, issueColumns, func(ctx context.Context, page database.Page) ([]issue.Issue, error) {
+		return db.ListIssues(ctx, filter, page)
+	}, issueID, issueRows)
+}
+
+func runExportChangelogs(ctx context.Context, app *App, args []string) error {
+	flags, dataset := newDatasetFlags("changelogs")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+
+	filter, err := dataset.filter()
+	if err != nil {
+		return err
+	}
+
+	db, err := app.Database(ctx)
+	if err != nil {
+		return err
+	}
+
+	return exportChunks(ctx, dataset, changelogColumns, func(ctx context.Context, page database.Page) ([]issue.Issue, error) {
+		return db.ListChangelogs(ctx, filter, page)
+	}, issueID, changelogRows)
+}
+
+func runExportMetrics(ctx context.Context, app *App, args []string) error {
+	flags, dataset := newDatasetFlags("metrics")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+
+	filter, err := dataset.filter()
+	if err != nil {
+		return err
+	}
+
+	db, err := app.Database(ctx)
+	if err != nil {
+		return err
+	}
+
+	return exportChunks(ctx, dataset, metricColumns, func(ctx context.Context, page database.Page) ([]analytics.WorkItem, error) {
+		return db.ListMetrics(ctx, filter, page)
+	}, func(item analytics.WorkItem) uint {
+		return item.IssueID
+	}, metricRows)
+}
+
+func runExportSprints(ctx context.Context, app *App, args []string) error {
+	flags := newFlagSet("export sprints", "export sprints [-format csv|ndjson|parquet] [-output file] [-states states] [-boards ids]")
+	format := flags.String("format", export.FormatCSV, "output format: csv, ndjson or parquet")
+	output := flags.String("output", "", "output file, defaults to stdout")
+	states := flags.String("states", "", "comma separated sprint states")
+	boards := flags.String("boards", "", "comma separated board ids")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+
+	if err := validateStreamFormat(*format); err != nil {
+		return err
+	}
+
+	boardIDs, err := parseUintList(*boards)
+	if err != nil {
+		return err
+	}
+
+	db, err := app.Database(ctx)
+	if err != nil {
+		return err
+	}
+
+	sprints, err := db.ListSprints(ctx, database.SprintFilter{States: splitList(*states), Boards: boardIDs}, database.Page{})
+	if err != nil {
+		return err
+	}
+
+	return writeOutput(*output, func(w io.Writer) error {
+		encoder, err := export.NewEncoder(w, *format, sprintColumns)
+		if err != nil {
+			return err
+		}
+
+		if err := encoder.Encode(sprintRows(sprints)); err != nil {
+			return err
+		}
+
+		return encoder.Close()
+	})
+}
+
+// exportChunks reads keyset pages of chunk size until a short page, encoding
+// each one before reading the next, so memory stays flat on large tables.
+func exportChunks[T any](ctx context.Context, dataset datasetFlags, columns []export.Column,
+	list func(ctx context.Context, page database.Page) ([]T, error), id func(T) uint, rows func([]T) [][]any) error {
+	return writeOutput(*dataset.output, func(w io.Writer) error {
+		encoder, err := export.NewEncoder(w, *dataset.format, columns)
+		if err != nil {
+			return err
+		}
+
+		page := database.Page{Limit: *dataset.chunk}
+		for {
+			items, err := list(ctx, page)
+			if err != nil {
+				return fmt.Errorf("while reading the rows after id %d: %w", page.After, err)
+			}
+
+			if len(items) != 0 {
+				if err := encoder.Encode(rows(items)); err != nil {
+					return err
+				}
+			}
+
+			if len(items) < page.Limit {
+				break
+			}
+
+			page.After = id(items[len(items)-1])
+		}
+
+		return encoder.Close()
+	})
+}
+
+func validateStreamFormat(format string) error {
+	switch format {
+	case export.FormatCSV, export.FormatNDJSON, export.FormatParquet:
+		return nil
+	default:
+		return fmt.Errorf("%w: unknown format %q", UsageErr, format)
+	}
+}
+
+func issueID(i issue.Issue) uint {
+	return i.ID
+}
+
+func issueRows(issues []issue.Issue) [][]any {
+	output := make([][]any, len(issues), len(issues))
+	for index, i := range issues {
+		var parentKey, sprintName, assigneeID, assignee string
+		var sprintID any
+		if i.Parent != nil {
+			parentKey = i.Parent.Key
+		}
+
+		if i.Sprint != nil {
+			sprintID, sprintName = i.Sprint.ID, i.Sprint.Name
+		}
+
+		if i.Assignee != nil {
+			assigneeID, assignee = i.Assignee.ID, i.Assignee.DisplayName
+		}
+
+		labels := make([]string, len(i.Labels), len(i.Labels))
+		for l, label := range i.Labels {
+			labels[l] = string(label)
+		}
+
+		products := make([]string, len(i.Products), len(i.Products))
+		for p, product := range i.Products {
+			products[p] = product.Name
+		}
+
+		output[index] = []any{i.ID, i.Key, i.Summary, i.Status, i.StatusID, i.StatusCategory, i.IssueType, i.Project,
+			parentKey, sprintID, sprintName, strings.Join(labels, ","), assigneeID, assignee, i.Reporter.ID, i.Reporter.DisplayName,
+			i.StoryPoints, i.Flagged, strings.Join(products, ","), i.FixVersion, i.Locality, i.CreatedAt, i.UpdatedAt}
+	}
+
+	return output
+}
+
+func changelogRows(issues []issue.Issue) [][]any {
+	var output [][]any
+	for _, i := range issues {
+		for _, c := range i.Changelog {
+			output = append(output, []any{i.ID, i.Key, c.ID, c.Field, c.From, c.To, c.FromID, c.ToID, c.FromCategory, c.ToCategory, c.CreatedAt})
+		}
+	}
+
+	return output
+}
+
+func sprintRows(sprints []issue.Sprint) [][]any {
+	output := make([][]any, len(sprints), len(sprints))
+	for i, s := range sprints {
+		var boardID any
+		if s.BoardID != 0 {
+			boardID = s.BoardID
+		}
+
+		output[i] = []any{s.ID, s.Name, s.State, s.Goal, s.StartedAt, s.EndedAt, s.CompletedAt, boardID, s.BoardName}
+	}
+
+	return output
+}
+
+func metricRows(items []analytics.WorkItem) [][]any {
+	output := make([][]any, len(items), len(items))
+	for i, item := range items {
+		var sprintID, cycleTime, efficiency any
+		if item.SprintID != 0 {
+			sprintID = item.SprintID
+		}
+
+		if !item.DoneAt.IsZero() {
+			cycleTime = export.Hours(item.CycleTime)
+		}
+
+		if item.ActiveTime+item.WaitingTime > 0 {
+			efficiency = round(analytics.Efficiency(item.ActiveTime, item.WaitingTime))
+		}
+
+		output[i] = []any{item.IssueID, item.Key, item.Summary, item.Project, item.IssueType, item.Status, item.StatusCategory,
+			item.Assignee, item.StoryPoints, item.ParentKey, sprintID, item.SprintName, item.StartedAt, item.DoneAt, cycleTime,
+			export.Hours(item.ActiveTime), export.Hours(item.WaitingTime), export.Hours(item.BlockedTime), efficiency}
+	}
+
+	return output
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"jira-integration/internal/jira/fake"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// testDSNEnv points the end to end tests to a disposable Postgres database;
+// they are skipped without it.
+const testDSNEnv = "JIRA_TEST_DSN"
+
+func TestRun_EndToEnd(t *testing.T) {
+	dsn := os.Getenv(testDSNEnv)
+	if dsn == "" {
+		t.Skipf("%s is not set", testDSNEnv)
+	}
+
+	seed, err := fake.LoadSeed(filepath.Join("testdata", "seed.json"))
+	if err != nil {
+		t.Fatalf("LoadSeed() error = %v", err)
+	}
+
+	server := fake.NewServer(seed, fake.WithPageSize(2), fake.WithFaults(fake.Faults{
+		RateLimited: 1,
+		Latency:     time.Millisecond,
+	}))
+	defer server.Close()
+
+	t.Setenv("JIRA_URL", server.URL)
+	t.Setenv("JIRA_USERNAME", "user@example.com")
+	t.Setenv("JIRA_PASSWORD", "token")
+	t.Setenv("JIRA_DB_DSN", dsn)
+	t.Setenv("JIRA_PROFILE", "")
+
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "missing.yaml")
+	steps := [][]string{
+		{"sync", "statuses"},
+		{"sync", "boards", "-boards", "905"},
+		{"sync", "sprints", "-boards", "905"},
+		{"sync", "versions", "E2E"},
+		{"fetch", "project = E2E"},
+		{"export", "issues", "-format", "ndjson", "-projects", "End to End", "-output", filepath.Join(dir, "issues.ndjson")},
+		{"export", "changelogs", "-format", "ndjson", "-projects", "End to End", "-output", filepath.Join(dir, "changelogs.ndjson")},
+	}
+
+	for _, step := range steps {
+		if code := run(append([]string{"-config", configPath}, step...)); code != exitOK {
+			t.Fatalf("run(%v) = %d, want %d", step, code, exitOK)
+		}
+	}
+
+	issues := readRows(t, filepath.Join(dir, "issues.ndjson"))
+	if len(issues) != 3 {
+		t.Fatalf("export issues = %d rows, want 3", len(issues))
+	}
+
+	for _, row := range issues {
+		if row["sprint_id"] != float64(90038) {
+			t.Errorf("export issues row %v, want sprint 90038", row)
+		}
+	}
+
+	if changelogs := readRows(t, filepath.Join(dir, "changelogs.ndjson")); len(changelogs) != 5 {
+		t.Errorf("export changelogs = %d rows, want 5", len(changelogs))
+	}
+}
+
+func readRows(t *testing.T, path string) []map[string]any {
+	t.Helper()
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer file.Close()
+
+	var rows []map[string]any
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var row map[string]any
+		if err := json.Unmarshal(scanner.Bytes(), &row); err != nil {
+			t.Fatalf("Unmarshal() error = %v", err)
+		}
+
+		rows = append(rows, row)
+	}
+
+	return rows
+}
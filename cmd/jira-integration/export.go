@@ -0,0 +1,95 @@
+package main
+
+import (
+	"context"
+	"io"
+	"jira-integration/internal/database"
+	"jira-integration/internal/export"
+	"jira-integration/pkg/analytics"
+	"os"
+)
+
+type (
+	timeInStatusTable  []analytics.IssueStatusDuration
+	statusSummaryTable []analytics.StatusSummary
+)
+
+func (t timeInStatusTable) Header() []string {
+	return []string{"issue_id", "issue_key", "project", "issue_type", "status", "status_category", "visits", "calendar_hours", "business_hours"}
+}
+
+func (t timeInStatusTable) Rows() [][]any {
+	output := make([][]any, len(t), len(t))
+	for i, row := range t {
+		output[i] = []any{row.IssueID, row.IssueKey, row.Project, row.IssueType, row.Status, row.Category, row.Visits, export.Hours(row.Duration), export.Hours(row.BusinessDuration)}
+	}
+
+	return output
+}
+
+func (t statusSummaryTable) Header() []string {
+	return []string{"project", "status", "status_category", "issues", "calendar_mean_hours", "calendar_p50_hours", "calendar_p85_hours", "business_mean_hours", "business_p50_hours", "business_p85_hours"}
+}
+
+func (t statusSummaryTable) Rows() [][]any {
+	output := make([][]any, len(t), len(t))
+	for i, row := range t {
+		output[i] = []any{row.Project, row.Status, row.Category, row.Issues,
+			export.Hours(row.Calendar.Mean), export.Hours(row.Calendar.P50), export.Hours(row.Calendar.P85),
+			export.Hours(row.Business.Mean), export.Hours(row.Business.P50), export.Hours(row.Business.P85)}
+	}
+
+	return output
+}
+
+func runExportTimeInStatus(ctx context.Context, app *App, args []string) error {
+	flags := newFlagSet("export time-in-status", "export time-in-status [-format csv|json|table] [-output file] [-summary]")
+	format := flags.String("format", export.FormatCSV, "output format: csv, json or table")
+	output := flags.String("output", "", "output file, defaults to stdout")
+	projects := flags.String("projects", "", "comma separated project names")
+	issueTypes := flags.String("types", "", "comma separated issue types")
+	summary := flags.Bool("summary", false, "aggregate per project and status instead of per issue")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+
+	db, err := app.Database(ctx)
+	if err != nil {
+		return err
+	}
+
+	rows, err := db.ListStatusDurations(ctx, database.IssueFilter{
+		Projects:   splitList(*projects),
+		IssueTypes: splitList(*issueTypes),
+	})
+	if err != nil {
+		return err
+	}
+
+	var table export.Table = timeInStatusTable(rows)
+	if *summary {
+		table = statusSummaryTable(analytics.SummarizeStatusDurations(rows))
+	}
+
+	return writeOutput(*output, func(w io.Writer) error {
+		return export.Write(w, *format, table)
+	})
+}
+
+func writeOutput(path string, write func(w io.Writer) error) error {
+	if path == "" || path == "-" {
+		return write(os.Stdout)
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	if err := write(file); err != nil {
+		_ = file.Close()
+		return err
+	}
+
+	return file.Close()
+}
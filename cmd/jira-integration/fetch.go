@@ -0,0 +1,123 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"jira-integration/internal/export"
+	"jira-integration/pkg/logging"
+	"jira-integration/usecase"
+	"strings"
+)
+
+type (
+	issueDiffTable []usecase.IssueDiff
+)
+
+func (t issueDiffTable) Header() []string {
+	return []string{"issue_id", "issue_key", "field", "stored", "fetched"}
+}
+
+func (t issueDiffTable) Rows() [][]any {
+	var output [][]any
+	for _, diff := range t {
+		if diff.New {
+			output = append(output, []any{diff.ID, diff.Key, "", "", "new issue"})
+		}
+
+		for _, change := range diff.Changes {
+			output = append(output, []any{diff.ID, diff.Key, change.Field, change.Stored, change.Fetched})
+		}
+	}
+
+	return output
+}
+
+func runFetch(ctx context.Context, app *App, args []string) error {
+	flags := newFlagSet("fetch", "fetch [-force] [-dry-run [-format table|csv|json] [-output file]] [-jql] <query>")
+	jql := flags.String("jql", "", "JQL query")
+	force := flags.Bool("force", false, "fetch the stored issues even when they are up to date, backfilling fields added since they were stored")
+	dryRun := flags.Bool("dry-run", false, "compare the fetched issues with the stored ones field by field, writing nothing")
+	format := flags.String("format", export.FormatTable, "dry run output format: csv, json or table")
+	output := flags.String("output", "", "dry run output file, defaults to stdout")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+
+	if *jql == "" {
+		*jql = strings.Join(flags.Args(), " ")
+	}
+
+	if *jql == "" {
+		return fmt.Errorf("%w: jql query is required", UsageErr)
+	}
+
+	if *dryRun {
+		return runFetchDryRun(ctx, app, *jql, *format, *output)
+	}
+
+	client, err := app.Jira(ctx)
+	if err != nil {
+		return err
+	}
+
+	db, err := app.Database(ctx)
+	if err != nil {
+		return err
+	}
+
+	calculator, err := app.Calculator()
+	if err != nil {
+		return err
+	}
+
+	refreshMetricsUseCase := usecase.NewRefreshMetricsUseCase(calculator, db)
+	fetchUseCase := usecase.NewFetchUseCase(client, db, refreshMetricsUseCase.Execute)
+	streamUseCase := usecase.NewStreamUseCase(client, fetchUseCase.Execute, db).WithObserver(app.Metrics.ObserveIssue).WithForce(*force)
+	logging.FromContext(ctx).Info("fetching issues", "jql", *jql)
+	return streamUseCase.Execute(ctx, *jql)
+}
+
+// runFetchDryRun maps the issues as a fetch would and diffs them against the
+// stored ones. Nothing is written: not the issues, their metrics, the archive
+// nor the schema.
+func runFetchDryRun(ctx context.Context, app *App, jql, format, output string) error {
+	app.Profile.Jira.Archive = false
+	client, err := app.Jira(ctx)
+	if err != nil {
+		return err
+	}
+
+	db, err := app.open()
+	if err != nil {
+		return err
+	}
+
+	var diffs issueDiffTable
+	var summary usecase.DiffSummary
+	diffDatabase := usecase.NewDiffDatabase(db, func(ctx context.Context, diff usecase.IssueDiff) error {
+		summary.Add(diff)
+		if diff.Changed() {
+			diffs = append(diffs, diff)
+		}
+
+		return nil
+	})
+
+	fetchUseCase := usecase.NewFetchUseCase(client, diffDatabase)
+	logger := logging.FromContext(ctx)
+	logger.Info("comparing issues", "jql", jql)
+	if err := usecase.NewStreamUseCase(client, fetchUseCase.Execute, diffDatabase).Execute(ctx, jql); err != nil {
+		return err
+	}
+
+	logger.Info("dry run finished", "compared", summary.Compared, "new", summary.New, "changed", summary.Changed, "unchanged", summary.Unchanged())
+	return writeOutput(output, func(w io.Writer) error {
+		if err := export.Write(w, format, diffs); err != nil || format != export.FormatTable {
+			return err
+		}
+
+		_, err := fmt.Fprintf(w, "\n%d issues compared: %d new, %d changed, %d unchanged\n", summary.Compared, summary.New, summary.Changed, summary.Unchanged())
+		return err
+	})
+}
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"jira-integration/internal/database"
+	"jira-integration/internal/export"
+	"jira-integration/pkg/report"
+	"jira-integration/usecase"
+	"time"
+)
+
+const (
+	dateLayout = "2006-01-02"
+)
+
+type (
+	cumulativeFlowTable report.CumulativeFlow
+)
+
+func (t cumulativeFlowTable) Header() []string {
+	return append([]string{"date"}, t.Columns...)
+}
+
+func (t cumulativeFlowTable) Rows() [][]any {
+	output := make([][]any, len(t.Days), len(t.Days))
+	for i, d := range t.Days {
+		row := []any{d.Date.Format(dateLayout)}
+		for _, column := range t.Columns {
+			row = append(row, d.Counts[column])
+		}
+
+		output[i] = row
+	}
+
+	return output
+}
+
+func runReportCFD(ctx context.Context, app *App, args []string) error {
+	flags := newFlagSet("report cfd", "report cfd [-projects names | -jql query] [-from date] [-to date] [-by status|category] [-svg file]")
+	projects := flags.String("projects", "", "comma separated project names")
+	jql := flags.String("jql", "", "JQL query resolved against Jira to pick the stored issues")
+	from := flags.String("from", "", "first day, as YYYY-MM-DD, defaults to 30 days before -to")
+	to := flags.String("to", "", "last day, as YYYY-MM-DD, defaults to today")
+	by := flags.String("by", report.FlowByCategory, "group issues by status or category")
+	format := flags.String("format", export.FormatTable, "output format: table, csv or json")
+	output := flags.String("output", "", "output file, defaults to stdout")
+	svg := flags.String("svg", "", "also render the diagram to this file")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+
+	if *by != report.FlowByStatus && *by != report.FlowByCategory {
+		return fmt.Errorf("%w: unknown grouping %q", UsageErr, *by)
+	}
+
+	calculator, err := app.Calculator()
+	if err != nil {
+		return err
+	}
+
+	location := calculator.Config().Calendar.Location
+	now := time.Now().In(location)
+	toDate, err := parseDate(*to, location, time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, location))
+	if err != nil {
+		return err
+	}
+
+	fromDate, err := parseDate(*from, location, toDate.AddDate(0, 0, -30))
+	if err != nil {
+		return err
+	}
+
+	if fromDate.After(toDate) {
+		return fmt.Errorf("%w: -from is after -to", UsageErr)
+	}
+
+	filter := database.IssueFilter{Projects: splitList(*projects)}
+	if *jql != "" {
+		client, err := app.Jira(ctx)
+		if err != nil {
+			return err
+		}
+
+		if filter.IssueIDs, err = usecase.NewSearchIssuesUseCase(client).Execute(ctx, *jql); err != nil {
+			return err
+		}
+
+		if len(filter.IssueIDs) == 0 {
+			return fmt.Errorf("no issue matches %q", *jql)
+		}
+
+		if err := filter.Validate(); err != nil {
+			return fmt.Errorf("%w: narrow down %q", err, *jql)
+		}
+	}
+
+	db, err := app.Database(ctx)
+	if err != nil {
+		return err
+	}
+
+	flow, err := usecase.NewCumulativeFlowUseCase(db.Scope(filter)).Execute(ctx, fromDate, toDate, *by)
+	if err != nil {
+		return err
+	}
+
+	if *svg != "" {
+		if err := writeOutput(*svg, func(w io.Writer) error {
+			return export.WriteSVG(w, cumulativeFlowChart(flow))
+		}); err != nil {
+			return err
+		}
+	}
+
+	return writeOutput(*output, func(w io.Writer) error {
+		return export.Write(w, *format, cumulativeFlowTable(flow))
+	})
+}
+
+func cumulativeFlowChart(flow report.CumulativeFlow) export.Chart {
+	chart := export.Chart{Title: "Cumulative flow by " + flow.By, Stacked: true}
+	for _, d := range flow.Days {
+		chart.Labels = append(chart.Labels, d.Date.Format("01-02"))
+	}
+
+	for _, column := range flow.Columns {
+		series := export.Series{Name: column}
+		for _, d := range flow.Days {
+			series.Values = append(series.Values, float64(d.Counts[column]))
+		}
+
+		chart.Series = append(chart.Series, series)
+	}
+
+	return chart
+}
+
+func parseDate(value string, location *time.Location, fallback time.Time) (time.Time, error) {
+	if value == "" {
+		return fallback, nil
+	}
+
+	parsed, err := time.ParseInLocation(dateLayout, value, location)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: invalid date %q", UsageErr, value)
+	}
+
+	return parsed, nil
+}
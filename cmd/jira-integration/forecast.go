@@ -0,0 +1,178 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"jira-integration/internal/database"
+	"jira-integration/internal/export"
+	"jira-integration/pkg/forecast"
+	"jira-integration/usecase"
+	"strconv"
+	"time"
+)
+
+type (
+	howManyTable forecast.Forecast
+	whenTable    forecast.Forecast
+
+	forecastFlags struct {
+		*flag.FlagSet
+		projects    *string
+		issueTypes  *string
+		weeks       *int
+		trials      *int
+		seed        *uint64
+		percentiles *string
+		format      *string
+		output      *string
+	}
+)
+
+func (t howManyTable) Header() []string {
+	return []string{"percentile", "until", "items"}
+}
+
+func (t howManyTable) Rows() [][]any {
+	output := make([][]any, len(t.Outcomes), len(t.Outcomes))
+	for i, o := range t.Outcomes {
+		output[i] = []any{o.Percentile, t.Until.Format(dateLayout), o.Items}
+	}
+
+	return output
+}
+
+func (t whenTable) Header() []string {
+	return []string{"percentile", "remaining", "weeks", "date"}
+}
+
+func (t whenTable) Rows() [][]any {
+	output := make([][]any, len(t.Outcomes), len(t.Outcomes))
+	for i, o := range t.Outcomes {
+		output[i] = []any{o.Percentile, t.Remaining, o.Weeks, o.Date.Format(dateLayout)}
+	}
+
+	return output
+}
+
+func runForecastHowMany(ctx context.Context, app *App, args []string) error {
+	flags := newForecastFlags("forecast how-many", "forecast how-many -until date [-projects names] [-types types] [-weeks n]")
+	until := flags.String("until", "", "forecast until this day, as YYYY-MM-DD")
+	if err := parseFlags(flags.FlagSet, args); err != nil {
+		return err
+	}
+
+	if *until == "" {
+		return fmt.Errorf("%w: -until is required", UsageErr)
+	}
+
+	calculator, err := app.Calculator()
+	if err != nil {
+		return err
+	}
+
+	untilDate, err := parseDate(*until, calculator.Config().Calendar.Location, time.Time{})
+	if err != nil {
+		return err
+	}
+
+	if !untilDate.After(time.Now()) {
+		return fmt.Errorf("%w: -until must be in the future", UsageErr)
+	}
+
+	request, err := flags.request()
+	if err != nil {
+		return err
+	}
+
+	request.Until = untilDate
+	return runForecast(ctx, app, flags, request, func(f forecast.Forecast) export.Table {
+		return howManyTable(f)
+	})
+}
+
+func runForecastWhen(ctx context.Context, app *App, args []string) error {
+	flags := newForecastFlags("forecast when", "forecast when (-remaining n | -parent key) [-projects names] [-types types] [-weeks n]")
+	remaining := flags.Int("remaining", 0, "how many items are left")
+	parent := flags.String("parent", "", "count the open children of this epic or theme as the remaining items")
+	if err := parseFlags(flags.FlagSet, args); err != nil {
+		return err
+	}
+
+	if (*remaining <= 0) == (*parent == "") {
+		return fmt.Errorf("%w: either -remaining or -parent is required", UsageErr)
+	}
+
+	request, err := flags.request()
+	if err != nil {
+		return err
+	}
+
+	request.Remaining, request.Parent = *remaining, *parent
+	return runForecast(ctx, app, flags, request, func(f forecast.Forecast) export.Table {
+		return whenTable(f)
+	})
+}
+
+func newForecastFlags(name, usage string) forecastFlags {
+	flags := newFlagSet(name, usage)
+	return forecastFlags{
+		FlagSet:     flags,
+		projects:    flags.String("projects", "", "comma separated project names whose throughput is sampled"),
+		issueTypes:  flags.String("types", "", "comma separated issue types whose throughput is sampled"),
+		weeks:       flags.Int("weeks", 12, "weeks of throughput history to sample"),
+		trials:      flags.Int("trials", 10000, "simulations to run"),
+		seed:        flags.Uint64("seed", 0, "random seed, for reproducible results"),
+		percentiles: flags.String("percentiles", "50,70,85,95", "comma separated confidence levels"),
+		format:      flags.String("format", export.FormatTable, "output format: table, csv or json"),
+		output:      flags.String("output", "", "output file, defaults to stdout"),
+	}
+}
+
+func (f forecastFlags) request() (usecase.ForecastRequest, error) {
+	var percentiles []int
+	for _, item := range splitList(*f.percentiles) {
+		p, err := strconv.Atoi(item)
+		if err != nil || p <= 0 || p >= 100 {
+			return usecase.ForecastRequest{}, fmt.Errorf("%w: invalid percentile %q", UsageErr, item)
+		}
+
+		percentiles = append(percentiles, p)
+	}
+
+	return usecase.ForecastRequest{
+		Weeks:       *f.weeks,
+		Trials:      *f.trials,
+		Seed:        *f.seed,
+		Percentiles: percentiles,
+	}, nil
+}
+
+func runForecast(ctx context.Context, app *App, flags forecastFlags, request usecase.ForecastRequest, table func(forecast.Forecast) export.Table) error {
+	db, err := app.Database(ctx)
+	if err != nil {
+		return err
+	}
+
+	scope := db.Scope(database.IssueFilter{
+		Projects:   splitList(*flags.projects),
+		IssueTypes: splitList(*flags.issueTypes),
+	})
+
+	result, err := usecase.NewForecastUseCase(scope, db).Execute(ctx, request)
+	if err != nil {
+		return err
+	}
+
+	return writeOutput(*flags.output, func(w io.Writer) error {
+		if *flags.format == export.FormatJSON {
+			encoder := json.NewEncoder(w)
+			encoder.SetIndent("", "  ")
+			return encoder.Encode(result)
+		}
+
+		return export.Write(w, *flags.format, table(result))
+	})
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"context"
+	"jira-integration/internal/config"
+	"jira-integration/internal/database"
+	"jira-integration/internal/jira"
+	"jira-integration/internal/scheduler"
+	"jira-integration/internal/telemetry"
+	"jira-integration/pkg/issue"
+	"jira-integration/usecase"
+)
+
+type (
+	// countingDatabase counts what the sync jobs save, by kind, so each job
+	// reports the items it synced.
+	countingDatabase struct {
+		*database.Gorm
+		sprints, boards, statuses, versions int
+	}
+)
+
+func (c *countingDatabase) SaveSprint(ctx context.Context, s issue.Sprint) error {
+	return counted(&c.sprints, c.Gorm.SaveSprint(ctx, s))
+}
+
+func (c *countingDatabase) SaveBoard(ctx context.Context, b issue.Board) error {
+	return counted(&c.boards, c.Gorm.SaveBoard(ctx, b))
+}
+
+func (c *countingDatabase) SaveStatus(ctx context.Context, s issue.Status) error {
+	return counted(&c.statuses, c.Gorm.SaveStatus(ctx, s))
+}
+
+func (c *countingDatabase) SaveVersion(ctx context.Context, v issue.Version) error {
+	return counted(&c.versions, c.Gorm.SaveVersion(ctx, v))
+}
+
+// counted increments n unless the save failed.
+func counted(n *int, err error) error {
+	if err != nil {
+		return err
+	}
+
+	*n++
+	return nil
+}
+
+func newTask(jobConfig config.Job, client *jira.Client, db *database.Gorm, metrics *usecase.RefreshMetricsUseCase, observer usecase.IssueObserver) scheduler.Task {
+	switch jobConfig.Type {
+	case config.SprintsJobType:
+		if len(jobConfig.Boards) != 0 {
+			return func(ctx context.Context) (int, error) {
+				counter := &countingDatabase{Gorm: db}
+				err := usecase.NewDiscoverSprintsUseCase(client, counter).Execute(ctx, jobConfig.Boards, jobConfig.States)
+				return counter.sprints, err
+			}
+		}
+
+		return func(ctx context.Context) (int, error) {
+			counter := &countingDatabase{Gorm: db}
+			err := usecase.NewSyncSprintsUseCase(client, counter).Execute(ctx, jobConfig.States)
+			return counter.sprints, err
+		}
+	case config.BoardsJobType:
+		return func(ctx context.Context) (int, error) {
+			counter := &countingDatabase{Gorm: db}
+			uc := usecase.NewSyncBoardsUseCase(client, counter)
+			if len(jobConfig.Boards) != 0 {
+				err := uc.Execute(ctx, "", jobConfig.Boards)
+				return counter.boards, err
+			}
+
+			for _, project := range jobConfig.Projects {
+				if err := uc.Execute(ctx, project, nil); err != nil {
+					return counter.boards, err
+				}
+			}
+
+			return counter.boards, nil
+		}
+	case config.StatusesJobType:
+		return func(ctx context.Context) (int, error) {
+			counter := &countingDatabase{Gorm: db}
+			err := usecase.NewSyncStatusesUseCase(client, counter).Execute(ctx)
+			return counter.statuses, err
+		}
+	case config.VersionsJobType:
+		return func(ctx context.Context) (int, error) {
+			counter := &countingDatabase{Gorm: db}
+			err := usecase.NewSyncVersionsUseCase(client, counter).Execute(ctx, jobConfig.Projects)
+			return counter.versions, err
+		}
+	default:
+		return func(ctx context.Context) (int, error) {
+			var count int
+			fetchUseCase := usecase.NewFetchUseCase(client, db, metrics.Execute)
+			publisher := func(ctx context.Context, issueID uint) error {
+				if err := fetchUseCase.Execute(ctx, issueID); err != nil {
+					return err
+				}
+
+				count++
+				return nil
+			}
+
+			err := usecase.NewStreamUseCase(client, publisher, db).WithObserver(observer).Execute(ctx, jobConfig.JQL)
+			return count, err
+		}
+	}
+}
+
+// observeTask records the last successful run of the job.
+func observeTask(name string, task scheduler.Task, metrics *telemetry.Metrics) scheduler.Task {
+	return func(ctx context.Context) (int, error) {
+		count, err := task(ctx)
+		metrics.ObserveRun(name, err)
+		return count, err
+	}
+}
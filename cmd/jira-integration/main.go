@@ -0,0 +1,289 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"jira-integration/internal/config"
+	"jira-integration/internal/telemetry"
+	"jira-integration/pkg/logging"
+	"jira-integration/pkg/tracing"
+	"log/slog"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	exitOK          = 0
+	exitFailure     = 1
+	exitUsage       = 2
+	exitConfig      = 3
+	exitInterrupted = 130
+
+	tracingShutdownTimeout = 5 * time.Second
+)
+
+var (
+	UsageErr = errors.New("usage error")
+
+	tracer = otel.Tracer("jira-integration/cmd/jira-integration")
+
+	commands = []Command{
+		{Name: "fetch", Summary: "fetch the issues matching a JQL query", Run: runFetch},
+		{Name: "sync", Summary: "sync Jira entities", Subcommands: []Command{
+			{Name: "sprints", Summary: "refresh stored sprints by state, or discover every sprint of the boards", Run: runSyncSprints},
+			{Name: "boards", Summary: "sync Agile boards and their status to column mapping", Run: runSyncBoards},
+			{Name: "versions", Summary: "sync the versions of the given projects", Run: runSyncVersions},
+			{Name: "statuses", Summary: "sync the status catalog and categorize stored transitions", Run: runSyncStatuses},
+		}},
+		{Name: "metrics", Summary: "manage the computed flow metrics", Subcommands: []Command{
+			{Name: "refresh", Summary: "recompute lead time, cycle time and time in status of every stored issue", Run: runMetricsRefresh},
+		}},
+		{Name: "export", Summary: "export stored data and computed metrics", Subcommands: []Command{
+			{Name: "time-in-status", Summary: "time each issue spent per status, in calendar and business hours", Run: runExportTimeInStatus},
+			{Name: "issues", Summary: "stream the stored issues to csv, ndjson or parquet", Run: runExportIssues},
+			{Name: "changelogs", Summary: "stream the changelog of the stored issues to csv, ndjson or parquet", Run: runExportChangelogs},
+			{Name: "sprints", Summary: "stream the stored sprints to csv, ndjson or parquet", Run: runExportSprints},
+			{Name: "metrics", Summary: "stream the computed flow metrics of the stored issues to csv, ndjson or parquet", Run: runExportMetrics},
+		}},
+		{Name: "report", Summary: "build agile reports from the stored history", Subcommands: []Command{
+			{Name: "sprint", Summary: "commitment, scope changes, completion and velocity of sprints", Run: runReportSprint},
+			{Name: "burndown", Summary: "daily burndown and burnup series of sprints, with an optional SVG chart", Run: runReportBurndown},
+			{Name: "cfd", Summary: "cumulative flow of a project or JQL scope over a date range", Run: runReportCFD},
+			{Name: "aging", Summary: "age of the work in progress against the historical cycle time percentiles", Run: runReportAging},
+			{Name: "flow-efficiency", Summary: "active versus waiting and blocked time per issue, epic or sprint", Run: runReportFlowEfficiency},
+			{Name: "rollup", Summary: "progress and forecast of epics and themes from the issues under them", Run: runReportRollup},
+			{Name: "estimation", Summary: "story points against cycle time, outliers and re-estimation frequency", Run: runReportEstimation},
+		}},
+		{Name: "forecast", Summary: "Monte Carlo forecasts from the weekly throughput", Subcommands: []Command{
+			{Name: "how-many", Summary: "how many items will be done until a date", Run: runForecastHowMany},
+			{Name: "when", Summary: "when the remaining items, or the open children of an epic, will be done", Run: runForecastWhen},
+		}},
+		{Name: "reprocess", Summary: "rebuild the stored issues and sprints from the archived Jira responses", Run: runReprocess},
+		{Name: "migrate", Summary: "create or update the database schema", Run: runMigrate},
+		{Name: "status", Summary: "show what is stored and the last job runs", Run: runStatus},
+		{Name: "daemon", Summary: "run the configured jobs on their schedules", Run: runDaemon, Serve: true},
+		{Name: "api", Summary: "serve the stored issues, sprints and metrics as a read-only JSON API", Run: runAPI, Serve: true},
+	}
+)
+
+type (
+	// Command is a node of the command tree. Serve commands run until
+	// interrupted and expose their metrics over HTTP, the others push them
+	// once done.
+	Command struct {
+		Name        string
+		Summary     string
+		Run         func(ctx context.Context, app *App, args []string) error
+		Subcommands []Command
+		Serve       bool
+	}
+)
+
+func main() {
+	os.Exit(run(os.Args[1:]))
+}
+
+func run(args []string) int {
+	flags := flag.NewFlagSet("jira-integration", flag.ContinueOnError)
+	configPath := flags.String("config", defaultConfigPath(), "path to the configuration file")
+	profileName := flags.String("profile", "", "configuration profile to use")
+	logFormat := flags.String("log-format", envOrDefault("JIRA_LOG_FORMAT", logging.FormatText), "log format, text or json")
+	logLevel := flags.String("log-level", envOrDefault("JIRA_LOG_LEVEL", "info"), "minimum log level: debug, info, warn or error")
+	flags.Usage = func() {
+		printUsage(flags, commands, "jira-integration")
+	}
+
+	if err := flags.Parse(args); err != nil {
+		return exitCode(fmt.Errorf("%w: %v", UsageErr, err))
+	}
+
+	logger, err := logging.NewLogger(os.Stderr, *logFormat, *logLevel)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitCode(fmt.Errorf("%w: %v", UsageErr, err))
+	}
+
+	slog.SetDefault(logger)
+
+	command, rest, path, err := resolve(commands, flags.Args(), "jira-integration")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		flags.Usage()
+		return exitCode(err)
+	}
+
+	profile, err := config.Load(*configPath, *profileName)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitCode(err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	app := NewApp(profile)
+	defer app.Close()
+
+	shutdownTracing, err := telemetry.SetupTracing(ctx)
+	if err != nil {
+		logger.Warn("tracing is disabled", "error", err)
+	}
+
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), tracingShutdownTimeout)
+		defer cancel()
+		if err := shutdownTracing(ctx); err != nil {
+			logger.Warn("while flushing spans", "error", err)
+		}
+	}()
+
+	// serve commands run their jobs each under a run and a trace of its own
+	name := strings.TrimPrefix(path, "jira-integration ")
+	span := trace.SpanFromContext(ctx)
+	if !command.Serve {
+		var runID string
+		ctx, runID = logging.WithRun(ctx, name)
+		ctx, span = tracer.Start(ctx, name, trace.WithAttributes(attribute.String("job.run_id", runID)))
+	}
+
+	err = command.Run(ctx, app, rest)
+	if !command.Serve {
+		tracing.End(span, &err)
+	}
+	if !command.Serve && !errors.Is(err, flag.ErrHelp) {
+		app.Metrics.ObserveRun(name, err)
+		if pushErr := app.PushMetrics(name); pushErr != nil {
+			logging.FromContext(ctx).Warn("while pushing metrics", "error", pushErr)
+		}
+	}
+
+	switch {
+	case err == nil, errors.Is(err, flag.ErrHelp):
+	case errors.Is(err, UsageErr):
+		fmt.Fprintf(os.Stderr, "%s: %v\n", path, err)
+	default:
+		logging.FromContext(ctx).Error("command failed", "error", err)
+	}
+
+	return exitCode(err)
+}
+
+func resolve(available []Command, args []string, path string) (Command, []string, string, error) {
+	if len(args) == 0 {
+		return Command{}, nil, path, fmt.Errorf("%w: missing command", UsageErr)
+	}
+
+	for _, command := range available {
+		if command.Name != args[0] {
+			continue
+		}
+
+		path = path + " " + command.Name
+		if len(command.Subcommands) != 0 {
+			return resolve(command.Subcommands, args[1:], path)
+		}
+
+		return command, args[1:], path, nil
+	}
+
+	return Command{}, nil, path, fmt.Errorf("%w: unknown command %q", UsageErr, args[0])
+}
+
+func printUsage(flags *flag.FlagSet, available []Command, name string) {
+	out := flags.Output()
+	_, _ = fmt.Fprintf(out, "Usage: %s [flags] <command> [arguments]\n\nCommands:\n", name)
+	printCommands(out, available, "  ")
+	_, _ = fmt.Fprintln(out, "\nFlags:")
+	flags.PrintDefaults()
+}
+
+func printCommands(out interface{ Write([]byte) (int, error) }, available []Command, indent string) {
+	for _, command := range available {
+		_, _ = fmt.Fprintf(out, "%s%-*s %s\n", indent, 16-len(indent)+2, command.Name, command.Summary)
+		printCommands(out, command.Subcommands, indent+"  ")
+	}
+}
+
+func exitCode(err error) int {
+	switch {
+	case err == nil, errors.Is(err, flag.ErrHelp):
+		return exitOK
+	case errors.Is(err, UsageErr):
+		return exitUsage
+	case errors.Is(err, config.InvalidConfigErr), errors.Is(err, config.ProfileNotFoundErr):
+		return exitConfig
+	case errors.Is(err, context.Canceled):
+		return exitInterrupted
+	default:
+		return exitFailure
+	}
+}
+
+func envOrDefault(env, fallback string) string {
+	if value := os.Getenv(env); value != "" {
+		return value
+	}
+
+	return fallback
+}
+
+func defaultConfigPath() string {
+	return envOrDefault("JIRA_CONFIG", "jira-integration.yaml")
+}
+
+func newFlagSet(name, usage string) *flag.FlagSet {
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	flags.Usage = func() {
+		_, _ = fmt.Fprintf(flags.Output(), "Usage: jira-integration %s\n", usage)
+		flags.PrintDefaults()
+	}
+
+	return flags
+}
+
+func parseFlags(flags *flag.FlagSet, args []string) error {
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+
+		return fmt.Errorf("%w: %v", UsageErr, err)
+	}
+
+	return nil
+}
+
+func parseUintList(value string) ([]uint, error) {
+	var output []uint
+	for _, item := range splitList(value) {
+		parsed, err := strconv.ParseUint(item, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid id %q", UsageErr, item)
+		}
+
+		output = append(output, uint(parsed))
+	}
+
+	return output, nil
+}
+
+func splitList(value string) []string {
+	var output []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			output = append(output, item)
+		}
+	}
+
+	return output
+}
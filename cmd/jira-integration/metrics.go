@@ -0,0 +1,26 @@
+package main
+
+import (
+	"context"
+	"jira-integration/usecase"
+)
+
+func runMetricsRefresh(ctx context.Context, app *App, args []string) error {
+	flags := newFlagSet("metrics refresh", "metrics refresh")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+
+	db, err := app.Database(ctx)
+	if err != nil {
+		return err
+	}
+
+	calculator, err := app.Calculator()
+	if err != nil {
+		return err
+	}
+
+	refreshMetricsUseCase := usecase.NewRefreshMetricsUseCase(calculator, db)
+	return usecase.NewBackfillMetricsUseCase(refreshMetricsUseCase, db).Execute(ctx)
+}
@@ -0,0 +1,25 @@
+package main
+
+import (
+	"context"
+	"jira-integration/pkg/logging"
+)
+
+func runMigrate(ctx context.Context, app *App, args []string) error {
+	flags := newFlagSet("migrate", "migrate")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+
+	db, err := app.open()
+	if err != nil {
+		return err
+	}
+
+	if err := db.Migrate(ctx); err != nil {
+		return err
+	}
+
+	logging.FromContext(ctx).Info("database schema is up to date")
+	return nil
+}
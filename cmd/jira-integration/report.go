@@ -0,0 +1,554 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"jira-integration/internal/database"
+	"jira-integration/internal/export"
+	"jira-integration/pkg/report"
+	"jira-integration/usecase"
+	"math"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type (
+	sprintReportTable     []report.SprintReport
+	sprintReportItemTable []report.SprintReport
+)
+
+func (t sprintReportTable) Header() []string {
+	return []string{"sprint_id", "sprint", "board_id", "state", "started_at", "ended_at",
+		"committed_issues", "committed_points", "added_issues", "added_points", "removed_issues", "removed_points",
+		"completed_issues", "completed_points", "carry_over_issues", "carry_over_points",
+		"completion_ratio", "velocity", "rolling_velocity"}
+}
+
+func (t sprintReportTable) Rows() [][]any {
+	output := make([][]any, len(t), len(t))
+	for i, r := range t {
+		output[i] = []any{r.Sprint.ID, r.Sprint.Name, r.Sprint.BoardID, r.Sprint.State, r.Sprint.StartedAt, report.SprintEnd(r.Sprint, r.Sprint.EndedAt),
+			r.Committed.Issues, r.Committed.Points, r.Added.Issues, r.Added.Points, r.Removed.Issues, r.Removed.Points,
+			r.Completed.Issues, r.Completed.Points, r.CarryOver.Issues, r.CarryOver.Points,
+			round(r.CompletionRatio), r.Velocity, round(r.RollingVelocity)}
+	}
+
+	return output
+}
+
+func (t sprintReportItemTable) Header() []string {
+	return []string{"sprint_id", "sprint", "issue_id", "issue_key", "points", "committed", "added", "removed", "completed", "carried_over"}
+}
+
+func (t sprintReportItemTable) Rows() [][]any {
+	var output [][]any
+	for _, r := range t {
+		for _, item := range r.Items {
+			output = append(output, []any{r.Sprint.ID, r.Sprint.Name, item.IssueID, item.Key, item.Points,
+				item.Committed, item.Added, item.Removed, item.Completed, item.CarriedOver})
+		}
+	}
+
+	return output
+}
+
+func runReportSprint(ctx context.Context, app *App, args []string) error {
+	flags := newFlagSet("report sprint", "report sprint (-sprint id | -board id [-last n]) [-window n] [-items]")
+	sprintID := flags.Uint("sprint", 0, "sprint id")
+	boardID := flags.Uint("board", 0, "board id, reports its last closed sprints")
+	last := flags.Int("last", 6, "how many closed sprints of the board")
+	window := flags.Int("window", 3, "sprints averaged in the rolling velocity")
+	items := flags.Bool("items", false, "list the issues of each sprint instead of the totals")
+	format := flags.String("format", export.FormatTable, "output format: table, csv or json")
+	output := flags.String("output", "", "output file, defaults to stdout")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+
+	if (*sprintID == 0) == (*boardID == 0) {
+		return fmt.Errorf("%w: either -sprint or -board is required", UsageErr)
+	}
+
+	db, err := app.Database(ctx)
+	if err != nil {
+		return err
+	}
+
+	calculator, err := app.Calculator()
+	if err != nil {
+		return err
+	}
+
+	reporter := report.NewSprintReporter(calculator.Config().Done)
+	reports, err := usecase.NewSprintReportUseCase(reporter, db).Execute(ctx, usecase.SprintReportRequest{
+		SprintID: *sprintID,
+		BoardID:  *boardID,
+		Last:     *last,
+		Window:   *window,
+	})
+	if err != nil {
+		return err
+	}
+
+	var table export.Table = sprintReportTable(reports)
+	if *items {
+		table = sprintReportItemTable(reports)
+	}
+
+	return writeOutput(*output, func(w io.Writer) error {
+		return export.Write(w, *format, table)
+	})
+}
+
+func round(value float64) float64 {
+	return math.Round(value*100) / 100
+}
+
+const (
+	chartBurndown = "burndown"
+	chartBurnup   = "burnup"
+)
+
+type (
+	burndownTable    []report.Burndown
+	scopeChangeTable []report.Burndown
+)
+
+func (t burndownTable) Header() []string {
+	return []string{"sprint_id", "sprint", "date", "scope", "completed", "remaining", "ideal", "added", "removed"}
+}
+
+func (t burndownTable) Rows() [][]any {
+	var output [][]any
+	for _, b := range t {
+		for _, point := range b.Points {
+			output = append(output, []any{b.Sprint.ID, b.Sprint.Name, point.Date, point.Scope, point.Completed, point.Remaining,
+				round(point.Ideal), point.Added, point.Removed})
+		}
+	}
+
+	return output
+}
+
+func (t scopeChangeTable) Header() []string {
+	return []string{"sprint_id", "sprint", "at", "issue_id", "issue_key", "kind", "points"}
+}
+
+func (t scopeChangeTable) Rows() [][]any {
+	var output [][]any
+	for _, b := range t {
+		for _, change := range b.Changes {
+			output = append(output, []any{b.Sprint.ID, b.Sprint.Name, change.At, change.IssueID, change.IssueKey, change.Kind, change.Points})
+		}
+	}
+
+	return output
+}
+
+func runReportBurndown(ctx context.Context, app *App, args []string) error {
+	flags := newFlagSet("report burndown", "report burndown [-sprints ids] [-changes] [-svg file [-chart burndown|burnup]]")
+	sprints := flags.String("sprints", "", "comma separated sprint ids, defaults to every active sprint")
+	changes := flags.Bool("changes", false, "list the scope changes instead of the daily series")
+	format := flags.String("format", export.FormatTable, "output format: table, csv or json")
+	output := flags.String("output", "", "output file, defaults to stdout")
+	svg := flags.String("svg", "", "also render a chart to this file, suffixed by the sprint id when there are many")
+	chart := flags.String("chart", chartBurndown, "chart to render: burndown or burnup")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+
+	if *chart != chartBurndown && *chart != chartBurnup {
+		return fmt.Errorf("%w: unknown chart %q", UsageErr, *chart)
+	}
+
+	sprintIDs, err := parseUintList(*sprints)
+	if err != nil {
+		return err
+	}
+
+	db, err := app.Database(ctx)
+	if err != nil {
+		return err
+	}
+
+	calculator, err := app.Calculator()
+	if err != nil {
+		return err
+	}
+
+	builder := report.NewBurndownBuilder(calculator.Config().Done)
+	burndowns, err := usecase.NewBurndownUseCase(builder, db).Execute(ctx, sprintIDs...)
+	if err != nil {
+		return err
+	}
+
+	if *svg != "" {
+		for _, b := range burndowns {
+			path := *svg
+			if len(burndowns) > 1 {
+				path = suffixPath(path, strconv.FormatUint(uint64(b.Sprint.ID), 10))
+			}
+
+			if err := writeOutput(path, func(w io.Writer) error {
+				return export.WriteSVG(w, burndownChart(b, *chart))
+			}); err != nil {
+				return err
+			}
+		}
+	}
+
+	var table export.Table = burndownTable(burndowns)
+	if *changes {
+		table = scopeChangeTable(burndowns)
+	}
+
+	return writeOutput(*output, func(w io.Writer) error {
+		return export.Write(w, *format, table)
+	})
+}
+
+func burndownChart(b report.Burndown, kind string) export.Chart {
+	labels := make([]string, len(b.Points), len(b.Points))
+	ideal := export.Series{Name: "ideal", Dashed: true}
+	remaining := export.Series{Name: "remaining"}
+	scope := export.Series{Name: "scope"}
+	completed := export.Series{Name: "completed"}
+	for i, point := range b.Points {
+		labels[i] = point.Date.Format("01-02")
+		ideal.Values = append(ideal.Values, point.Ideal)
+		remaining.Values = append(remaining.Values, chartValue(point.Remaining))
+		scope.Values = append(scope.Values, chartValue(point.Scope))
+		completed.Values = append(completed.Values, chartValue(point.Completed))
+	}
+
+	if kind == chartBurnup {
+		return export.Chart{Title: b.Sprint.Name + " burnup", Labels: labels, Series: []export.Series{scope, completed}}
+	}
+
+	return export.Chart{Title: b.Sprint.Name + " burndown", Labels: labels, Series: []export.Series{remaining, ideal}}
+}
+
+func chartValue(value *float64) float64 {
+	if value == nil {
+		return math.NaN()
+	}
+
+	return *value
+}
+
+func suffixPath(path, suffix string) string {
+	extension := filepath.Ext(path)
+	return strings.TrimSuffix(path, extension) + "-" + suffix + extension
+}
+
+type (
+	agingTable []report.AgingItem
+)
+
+func (t agingTable) Header() []string {
+	return []string{"issue_id", "issue_key", "summary", "project", "issue_type", "status", "assignee", "started_at",
+		"age_days", "p50_days", "p85_days", "p95_days", "samples", "baseline", "risk"}
+}
+
+func (t agingTable) Rows() [][]any {
+	output := make([][]any, len(t), len(t))
+	for i, item := range t {
+		output[i] = []any{item.IssueID, item.Key, item.Summary, item.Project, item.IssueType, item.Status, item.Assignee, item.StartedAt,
+			export.Days(item.Age), export.Days(item.CycleTime.P50), export.Days(item.CycleTime.P85), export.Days(item.CycleTime.P95),
+			item.Samples, item.Baseline, item.Risk}
+	}
+
+	return output
+}
+
+func runReportAging(ctx context.Context, app *App, args []string) error {
+	flags := newFlagSet("report aging", "report aging [-projects names] [-types types] [-weeks n] [-risky]")
+	projects := flags.String("projects", "", "comma separated project names")
+	issueTypes := flags.String("types", "", "comma separated issue types")
+	weeks := flags.Int("weeks", 26, "weeks of done issues the cycle time percentiles come from")
+	risky := flags.Bool("risky", false, "only list the issues past the 85th percentile")
+	format := flags.String("format", export.FormatTable, "output format: table, csv or json")
+	output := flags.String("output", "", "output file, defaults to stdout")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+
+	if *weeks <= 0 {
+		return fmt.Errorf("%w: -weeks must be positive", UsageErr)
+	}
+
+	db, err := app.Database(ctx)
+	if err != nil {
+		return err
+	}
+
+	scope := db.Scope(database.IssueFilter{
+		Projects:   splitList(*projects),
+		IssueTypes: splitList(*issueTypes),
+	})
+
+	items, err := usecase.NewAgingUseCase(scope).Execute(ctx, *weeks)
+	if err != nil {
+		return err
+	}
+
+	if *risky {
+		var filtered []report.AgingItem
+		for _, item := range items {
+			if item.Risk == report.RiskAtRisk || item.Risk == report.RiskCritical {
+				filtered = append(filtered, item)
+			}
+		}
+
+		items = filtered
+	}
+
+	return writeOutput(*output, func(w io.Writer) error {
+		return export.Write(w, *format, agingTable(items))
+	})
+}
+
+type (
+	flowEfficiencyTable []report.FlowEfficiency
+)
+
+func (t flowEfficiencyTable) Header() []string {
+	return []string{"group", "issues", "active_hours", "waiting_hours", "blocked_hours", "efficiency"}
+}
+
+func (t flowEfficiencyTable) Rows() [][]any {
+	output := make([][]any, len(t), len(t))
+	for i, row := range t {
+		group := row.Group
+		if row.SprintID != 0 {
+			group = fmt.Sprintf("%s (%d)", row.Group, row.SprintID)
+		}
+
+		output[i] = []any{group, row.Issues, export.Hours(row.ActiveTime), export.Hours(row.WaitingTime),
+			export.Hours(row.BlockedTime), round(row.Efficiency)}
+	}
+
+	return output
+}
+
+func runReportFlowEfficiency(ctx context.Context, app *App, args []string) error {
+	flags := newFlagSet("report flow-efficiency", "report flow-efficiency [-by issue|epic|sprint] [-projects names] [-types types] [-weeks n] [-wip]")
+	by := flags.String("by", report.EfficiencyByIssue, "group by issue, epic or sprint")
+	projects := flags.String("projects", "", "comma separated project names")
+	issueTypes := flags.String("types", "", "comma separated issue types")
+	weeks := flags.Int("weeks", 4, "weeks of done issues to include")
+	wip := flags.Bool("wip", false, "also include the issues in progress")
+	format := flags.String("format", export.FormatTable, "output format: table, csv or json")
+	output := flags.String("output", "", "output file, defaults to stdout")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+
+	if *by != report.EfficiencyByIssue && *by != report.EfficiencyByEpic && *by != report.EfficiencyBySprint {
+		return fmt.Errorf("%w: unknown grouping %q", UsageErr, *by)
+	}
+
+	db, err := app.Database(ctx)
+	if err != nil {
+		return err
+	}
+
+	scope := db.Scope(database.IssueFilter{
+		Projects:   splitList(*projects),
+		IssueTypes: splitList(*issueTypes),
+	})
+
+	rows, err := usecase.NewFlowEfficiencyUseCase(scope).Execute(ctx, *weeks, *wip, *by)
+	if err != nil {
+		return err
+	}
+
+	return writeOutput(*output, func(w io.Writer) error {
+		return export.Write(w, *format, flowEfficiencyTable(rows))
+	})
+}
+
+type (
+	rollupTable []report.Rollup
+)
+
+func (t rollupTable) Header() []string {
+	return []string{"key", "summary", "level", "status", "parent_key", "epics", "children", "done", "points", "done_points",
+		"statuses", "started_at", "last_done_at", "forecasted_at"}
+}
+
+func (t rollupTable) Rows() [][]any {
+	output := make([][]any, len(t), len(t))
+	for i, r := range t {
+		output[i] = []any{r.Key, r.Summary, r.Level, r.Status, r.ParentKey, r.Epics, r.Children, r.Done, r.Points, r.DonePoints,
+			formatCounts(r.Statuses), r.StartedAt, r.LastDoneAt, r.ForecastedAt}
+	}
+
+	return output
+}
+
+func runReportRollup(ctx context.Context, app *App, args []string) error {
+	flags := newFlagSet("report rollup", "report rollup [-level epic|theme] [-projects names] [-epic-types types] [-theme-types types]")
+	level := flags.String("level", "", "only list epics or themes")
+	projects := flags.String("projects", "", "comma separated project names")
+	epicTypes := flags.String("epic-types", "Epic", "comma separated issue types rolled up as epics")
+	themeTypes := flags.String("theme-types", "Theme", "comma separated issue types rolled up as themes")
+	format := flags.String("format", export.FormatTable, "output format: table, csv or json")
+	output := flags.String("output", "", "output file, defaults to stdout")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+
+	if *level != "" && *level != report.LevelEpic && *level != report.LevelTheme {
+		return fmt.Errorf("%w: unknown level %q", UsageErr, *level)
+	}
+
+	db, err := app.Database(ctx)
+	if err != nil {
+		return err
+	}
+
+	builder := report.NewRollupBuilder(splitList(*epicTypes), splitList(*themeTypes))
+	scope := db.Scope(database.IssueFilter{Projects: splitList(*projects)})
+	rollups, err := usecase.NewRollupUseCase(builder, scope).Execute(ctx)
+	if err != nil {
+		return err
+	}
+
+	if *level != "" {
+		var filtered []report.Rollup
+		for _, r := range rollups {
+			if r.Level == *level {
+				filtered = append(filtered, r)
+			}
+		}
+
+		rollups = filtered
+	}
+
+	return writeOutput(*output, func(w io.Writer) error {
+		return export.Write(w, *format, rollupTable(rollups))
+	})
+}
+
+// formatCounts writes the counts as "name=count" pairs sorted by name.
+func formatCounts(counts map[string]int) string {
+	names := make([]string, 0, len(counts))
+	for name := range counts {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+	pairs := make([]string, len(names), len(names))
+	for i, name := range names {
+		pairs[i] = fmt.Sprintf("%s=%d", name, counts[name])
+	}
+
+	return strings.Join(pairs, "; ")
+}
+
+const (
+	estimationPoints       = "points"
+	estimationOutliers     = "outliers"
+	estimationReestimation = "reestimation"
+)
+
+type (
+	pointStatsTable   []report.PointStats
+	outlierTable      []report.Outlier
+	reestimationTable []report.ReestimationStats
+)
+
+func (t pointStatsTable) Header() []string {
+	return []string{"project", "issue_type", "points", "issues", "mean_days", "p50_days", "p85_days", "p95_days", "min_days", "max_days", "outliers"}
+}
+
+func (t pointStatsTable) Rows() [][]any {
+	output := make([][]any, len(t), len(t))
+	for i, row := range t {
+		output[i] = []any{row.Project, row.IssueType, row.Points, row.Issues,
+			export.Days(row.CycleTime.Mean), export.Days(row.CycleTime.P50), export.Days(row.CycleTime.P85), export.Days(row.CycleTime.P95),
+			export.Days(row.Min), export.Days(row.Max), row.Outliers}
+	}
+
+	return output
+}
+
+func (t outlierTable) Header() []string {
+	return []string{"issue_id", "issue_key", "project", "issue_type", "points", "cycle_time_days", "threshold_days", "reestimations"}
+}
+
+func (t outlierTable) Rows() [][]any {
+	output := make([][]any, len(t), len(t))
+	for i, row := range t {
+		output[i] = []any{row.IssueID, row.Key, row.Project, row.IssueType, row.Points,
+			export.Days(row.CycleTime), export.Days(row.Threshold), row.Reestimations}
+	}
+
+	return output
+}
+
+func (t reestimationTable) Header() []string {
+	return []string{"project", "issue_type", "issues", "reestimated", "reestimated_after_start", "rate"}
+}
+
+func (t reestimationTable) Rows() [][]any {
+	output := make([][]any, len(t), len(t))
+	for i, row := range t {
+		output[i] = []any{row.Project, row.IssueType, row.Issues, row.Reestimated, row.AfterStart, round(row.Rate)}
+	}
+
+	return output
+}
+
+func runReportEstimation(ctx context.Context, app *App, args []string) error {
+	flags := newFlagSet("report estimation", "report estimation [-view points|outliers|reestimation] [-projects names] [-types types] [-weeks n]")
+	view := flags.String("view", estimationPoints, "cycle time per point value, outliers or re-estimation frequency")
+	projects := flags.String("projects", "", "comma separated project names")
+	issueTypes := flags.String("types", "", "comma separated issue types")
+	weeks := flags.Int("weeks", 26, "weeks of done issues to analyze")
+	format := flags.String("format", export.FormatTable, "output format: table, csv or json")
+	output := flags.String("output", "", "output file, defaults to stdout")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+
+	if *view != estimationPoints && *view != estimationOutliers && *view != estimationReestimation {
+		return fmt.Errorf("%w: unknown view %q", UsageErr, *view)
+	}
+
+	db, err := app.Database(ctx)
+	if err != nil {
+		return err
+	}
+
+	calculator, err := app.Calculator()
+	if err != nil {
+		return err
+	}
+
+	scope := db.Scope(database.IssueFilter{
+		Projects:   splitList(*projects),
+		IssueTypes: splitList(*issueTypes),
+	})
+
+	result, err := usecase.NewEstimationUseCase(calculator, scope).Execute(ctx, *weeks)
+	if err != nil {
+		return err
+	}
+
+	var table export.Table = pointStatsTable(result.Points)
+	switch *view {
+	case estimationOutliers:
+		table = outlierTable(result.Outliers)
+	case estimationReestimation:
+		table = reestimationTable(result.Reestimation)
+	}
+
+	return writeOutput(*output, func(w io.Writer) error {
+		return export.Write(w, *format, table)
+	})
+}
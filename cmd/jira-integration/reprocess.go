@@ -0,0 +1,37 @@
+package main
+
+import (
+	"context"
+	"jira-integration/internal/jira"
+	"jira-integration/pkg/logging"
+	"jira-integration/usecase"
+)
+
+func runReprocess(ctx context.Context, app *App, args []string) error {
+	flags := newFlagSet("reprocess", "reprocess")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+
+	db, err := app.Database(ctx)
+	if err != nil {
+		return err
+	}
+
+	calculator, err := app.Calculator()
+	if err != nil {
+		return err
+	}
+
+	archive := jira.NewArchiveClient(db)
+	refreshMetricsUseCase := usecase.NewRefreshMetricsUseCase(calculator, db)
+	fetchUseCase := usecase.NewFetchUseCase(archive, db, refreshMetricsUseCase.Execute)
+	reprocessUseCase := usecase.NewReprocessUseCase(archive, archive, db, fetchUseCase.Execute)
+	result, err := reprocessUseCase.Execute(ctx)
+	if err != nil {
+		return err
+	}
+
+	logging.FromContext(ctx).Info("reprocessed the archive", "sprints", result.Sprints, "issues", result.Issues)
+	return nil
+}
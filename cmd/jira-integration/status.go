@@ -0,0 +1,63 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+)
+
+func runStatus(ctx context.Context, app *App, args []string) error {
+	flags := newFlagSet("status", "status")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+
+	db, err := app.Database(ctx)
+	if err != nil {
+		return err
+	}
+
+	stats, err := db.GetStats(ctx)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintf(w, "profile\t%s\n", app.Profile.Name)
+	_, _ = fmt.Fprintf(w, "jira\t%s\n", app.Profile.Jira.URL)
+	_, _ = fmt.Fprintf(w, "issues\t%d\n", stats.Issues)
+	_, _ = fmt.Fprintf(w, "changelogs\t%d\n", stats.Changelogs)
+	_, _ = fmt.Fprintf(w, "sprints\t%d\n", stats.Sprints)
+	_, _ = fmt.Fprintf(w, "versions\t%d\n", stats.Versions)
+	_, _ = fmt.Fprintf(w, "last issue update\t%s\n", formatTime(stats.LastUpdatedAt))
+
+	if len(app.Profile.Daemon.Jobs) != 0 {
+		_, _ = fmt.Fprintln(w, "\nJOB\tSTARTED\tENDED\tCOUNT\tERROR")
+	}
+
+	for _, j := range app.Profile.Daemon.Jobs {
+		run, exists, err := db.GetLastRun(ctx, j.Name)
+		if err != nil {
+			return err
+		}
+
+		if !exists {
+			_, _ = fmt.Fprintf(w, "%s\tnever\t-\t-\t-\n", j.Name)
+			continue
+		}
+
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%s\n", j.Name, formatTime(run.StartedAt), formatTime(run.EndedAt), run.Count, run.Error)
+	}
+
+	return w.Flush()
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+
+	return t.Local().Format(time.DateTime)
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"jira-integration/usecase"
+)
+
+func runSyncSprints(ctx context.Context, app *App, args []string) error {
+	flags := newFlagSet("sync sprints", "sync sprints [-discover] [-boards ids] [state...]")
+	discover := flags.Bool("discover", false, "list the sprints of the boards instead of refreshing the stored ones")
+	boards := flags.String("boards", "", "comma separated board ids, defaults to the profile boards (implies -discover)")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+
+	boardIDs, err := parseUintList(*boards)
+	if err != nil {
+		return err
+	}
+
+	if len(boardIDs) != 0 {
+		*discover = true
+	}
+
+	if *discover && len(boardIDs) == 0 {
+		boardIDs = app.Profile.Jira.Boards
+	}
+
+	if *discover && len(boardIDs) == 0 {
+		return fmt.Errorf("%w: no boards given or configured", UsageErr)
+	}
+
+	if !*discover && flags.NArg() == 0 {
+		return fmt.Errorf("%w: missing state argument", UsageErr)
+	}
+
+	client, err := app.Jira(ctx)
+	if err != nil {
+		return err
+	}
+
+	db, err := app.Database(ctx)
+	if err != nil {
+		return err
+	}
+
+	if *discover {
+		return usecase.NewDiscoverSprintsUseCase(client, db).Execute(ctx, boardIDs, flags.Args())
+	}
+
+	return usecase.NewSyncSprintsUseCase(client, db).Execute(ctx, flags.Args())
+}
+
+func runSyncBoards(ctx context.Context, app *App, args []string) error {
+	flags := newFlagSet("sync boards", "sync boards [-project key] [-boards ids]")
+	project := flags.String("project", "", "only boards of this project key or id")
+	boards := flags.String("boards", "", "comma separated board ids, defaults to the profile boards")
+	all := flags.Bool("all", false, "sync every board visible to the user, ignoring the profile boards")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+
+	boardIDs, err := parseUintList(*boards)
+	if err != nil {
+		return err
+	}
+
+	if len(boardIDs) == 0 && *project == "" && !*all {
+		boardIDs = app.Profile.Jira.Boards
+	}
+
+	client, err := app.Jira(ctx)
+	if err != nil {
+		return err
+	}
+
+	db, err := app.Database(ctx)
+	if err != nil {
+		return err
+	}
+
+	return usecase.NewSyncBoardsUseCase(client, db).Execute(ctx, *project, boardIDs)
+}
+
+func runSyncVersions(ctx context.Context, app *App, args []string) error {
+	flags := newFlagSet("sync versions", "sync versions <project>...")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+
+	if flags.NArg() == 0 {
+		return fmt.Errorf("%w: missing project argument", UsageErr)
+	}
+
+	client, err := app.Jira(ctx)
+	if err != nil {
+		return err
+	}
+
+	db, err := app.Database(ctx)
+	if err != nil {
+		return err
+	}
+
+	return usecase.NewSyncVersionsUseCase(client, db).Execute(ctx, flags.Args())
+}
+
+func runSyncStatuses(ctx context.Context, app *App, args []string) error {
+	flags := newFlagSet("sync statuses", "sync statuses")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+
+	client, err := app.Jira(ctx)
+	if err != nil {
+		return err
+	}
+
+	db, err := app.Database(ctx)
+	if err != nil {
+		return err
+	}
+
+	return usecase.NewSyncStatusesUseCase(client, db).Execute(ctx)
+}
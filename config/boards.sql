@@ -0,0 +1,33 @@
+create or replace view board_status_columns as
+(
+select columns.board_id,
+       statuses.status_id,
+       columns.name                               as column_name,
+       columns.position,
+       columns.position > bounds.first_position as started,
+       columns.position = bounds.last_position  as done
+from board_columns columns
+         inner join board_column_statuses statuses
+                    on statuses.board_id = columns.board_id and statuses.position = columns.position
+         inner join (select board_id, min(position) first_position, max(position) last_position
+                     from board_columns
+                     group by board_id) bounds on bounds.board_id = columns.board_id
+    );
+
+---
+
+create or replace view issues_board_changelog as
+(
+select sprints.board_id,
+       issues.id                                                   as issue_id,
+       min(changelogs.created_at) filter (where columns.started) as started_at,
+       max(changelogs.created_at) filter (where columns.done)    as done_at
+from issues
+         inner join sprints on sprints.id = issues.sprint_id
+         inner join changelogs on changelogs.issue_id = issues.id and changelogs.field = 'status'
+         inner join board_status_columns columns
+                    on columns.board_id = sprints.board_id and columns.status_id = changelogs.to_id
+where story_points is not null
+group by sprints.board_id,
+         issues.id
+    );
@@ -5,9 +5,22 @@ select issues.id as               issue_id,
        max(done_at.created_at)    done_at
 from issues
          inner join changelogs started_at
-                    on issues.id = started_at.issue_id and started_at."to" in ('In Progress', 'In Development')
-         inner join changelogs done_at on issues.id = done_at.issue_id and done_at."to" in ('Done')
+                    on issues.id = started_at.issue_id and started_at.field = 'status' and started_at."to" in ('In Progress', 'In Development')
+         inner join changelogs done_at on issues.id = done_at.issue_id and done_at.field = 'status' and done_at."to" in ('Done')
 where story_points is not null
 group by issues.id,
          issues.story_points
     );
+
+---
+
+create or replace view issues_category_changelog as
+(
+select issues.id                                                                          as issue_id,
+       min(changelogs.created_at) filter (where changelogs.to_category = 'indeterminate') as started_at,
+       max(changelogs.created_at) filter (where changelogs.to_category = 'done')          as done_at
+from issues
+         inner join changelogs on issues.id = changelogs.issue_id and changelogs.field = 'status'
+where story_points is not null
+group by issues.id
+    );
@@ -0,0 +1,403 @@
+package api
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"jira-integration/internal/database"
+	"jira-integration/pkg/analytics"
+	"jira-integration/pkg/issue"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	defaultLimit = 100
+	maxLimit     = 500
+)
+
+var (
+	InvalidParameterErr = errors.New("invalid parameter")
+)
+
+type (
+	Database interface {
+		ListIssues(ctx context.Context, filter database.IssueFilter, page database.Page) ([]issue.Issue, error)
+		GetIssueByKey(ctx context.Context, key string) (issue.Issue, bool, error)
+		GetByKey(ctx context.Context, key string) (issue.Stamp, bool, error)
+		ListChangelog(ctx context.Context, issueID uint, page database.Page) ([]issue.Changelog, error)
+		ListSprints(ctx context.Context, filter database.SprintFilter, page database.Page) ([]issue.Sprint, error)
+		GetSprint(ctx context.Context, sprintID uint) (issue.Sprint, bool, error)
+		ListMetrics(ctx context.Context, filter database.IssueFilter, page database.Page) ([]analytics.WorkItem, error)
+	}
+
+	// Page is a page of a listing. NextCursor is empty on the last page.
+	Page[T any] struct {
+		Items      []T    `json:"items"`
+		NextCursor string `json:"next_cursor,omitempty"`
+	}
+
+	Error struct {
+		Error string `json:"error"`
+	}
+
+	// Server exposes the stored data as a read-only JSON API.
+	Server struct {
+		db  Database
+		mux *http.ServeMux
+	}
+)
+
+func NewServer(db Database) *Server {
+	s := &Server{
+		db:  db,
+		mux: http.NewServeMux(),
+	}
+
+	s.mux.HandleFunc("GET /issues", s.listIssues)
+	s.mux.HandleFunc("GET /issues/{key}", s.getIssue)
+	s.mux.HandleFunc("GET /issues/{key}/changelog", s.getIssueChangelog)
+	s.mux.HandleFunc("GET /sprints", s.listSprints)
+	s.mux.HandleFunc("GET /sprints/{id}", s.getSprint)
+	s.mux.HandleFunc("GET /sprints/{id}/issues", s.listSprintIssues)
+	s.mux.HandleFunc("GET /metrics", s.listMetrics)
+	return s
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// listIssues filters by project, type, status, sprint and updated range.
+// Every filter takes comma separated or repeated values.
+func (s *Server) listIssues(w http.ResponseWriter, r *http.Request) {
+	filter, err := issueFilter(r.URL.Query())
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	s.writeIssues(w, r, filter)
+}
+
+func (s *Server) listSprintIssues(w http.ResponseWriter, r *http.Request) {
+	sprintID, err := parseID(r.PathValue("id"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	filter, err := issueFilter(r.URL.Query())
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	filter.Sprints = []uint{sprintID}
+	s.writeIssues(w, r, filter)
+}
+
+func (s *Server) writeIssues(w http.ResponseWriter, r *http.Request, filter database.IssueFilter) {
+	page, err := parsePage(r.URL.Query())
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	issues, err := s.db.ListIssues(r.Context(), filter, page)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, newPage(issues, page.Limit, func(i issue.Issue) uint {
+		return i.ID
+	}))
+}
+
+func (s *Server) getIssue(w http.ResponseWriter, r *http.Request) {
+	i, exists, err := s.db.GetIssueByKey(r.Context(), r.PathValue("key"))
+	switch {
+	case err != nil:
+		writeError(w, http.StatusInternalServerError, err)
+	case !exists:
+		writeError(w, http.StatusNotFound, fmt.Errorf("issue %s not found", r.PathValue("key")))
+	default:
+		writeJSON(w, http.StatusOK, i)
+	}
+}
+
+func (s *Server) getIssueChangelog(w http.ResponseWriter, r *http.Request) {
+	page, err := parseOffsetPage(r.URL.Query())
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	stamp, exists, err := s.db.GetByKey(r.Context(), r.PathValue("key"))
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	if !exists {
+		writeError(w, http.StatusNotFound, fmt.Errorf("issue %s not found", r.PathValue("key")))
+		return
+	}
+
+	changelog, err := s.db.ListChangelog(r.Context(), stamp.ID, page)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, newOffsetPage(changelog, page))
+}
+
+func (s *Server) listSprints(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	boards, err := parseIDs(values(query, "board"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	page, err := parseOffsetPage(query)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	sprints, err := s.db.ListSprints(r.Context(), database.SprintFilter{
+		States: values(query, "state"),
+		Boards: boards,
+	}, page)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, newOffsetPage(sprints, page))
+}
+
+func (s *Server) getSprint(w http.ResponseWriter, r *http.Request) {
+	sprintID, err := parseID(r.PathValue("id"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	sprint, exists, err := s.db.GetSprint(r.Context(), sprintID)
+	switch {
+	case err != nil:
+		writeError(w, http.StatusInternalServerError, err)
+	case !exists:
+		writeError(w, http.StatusNotFound, fmt.Errorf("sprint %d not found", sprintID))
+	default:
+		writeJSON(w, http.StatusOK, sprint)
+	}
+}
+
+// listMetrics returns the computed flow metrics of the issues matching the
+// same filters as the issues listing.
+func (s *Server) listMetrics(w http.ResponseWriter, r *http.Request) {
+	filter, err := issueFilter(r.URL.Query())
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	page, err := parsePage(r.URL.Query())
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	items, err := s.db.ListMetrics(r.Context(), filter, page)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, newPage(items, page.Limit, func(item analytics.WorkItem) uint {
+		return item.IssueID
+	}))
+}
+
+func issueFilter(query url.Values) (database.IssueFilter, error) {
+	sprints, err := parseIDs(values(query, "sprint"))
+	if err != nil {
+		return database.IssueFilter{}, err
+	}
+
+	filter := database.IssueFilter{
+		Projects:   values(query, "project"),
+		IssueTypes: values(query, "type"),
+		Statuses:   values(query, "status"),
+		Sprints:    sprints,
+	}
+
+	if filter.UpdatedFrom, err = parseTime(query.Get("updated_from")); err != nil {
+		return database.IssueFilter{}, err
+	}
+
+	if filter.UpdatedTo, err = parseTime(query.Get("updated_to")); err != nil {
+		return database.IssueFilter{}, err
+	}
+
+	return filter, nil
+}
+
+// parsePage reads the opaque cursor, the base64 of the last id returned.
+func parsePage(query url.Values) (database.Page, error) {
+	limit, cursor, err := parseCursor(query)
+	if err != nil {
+		return database.Page{}, err
+	}
+
+	page := database.Page{Limit: limit}
+	if cursor != "" {
+		if page.After, err = parseID(cursor); err != nil {
+			return database.Page{}, fmt.Errorf("%w: cursor %q", InvalidParameterErr, query.Get("cursor"))
+		}
+	}
+
+	return page, nil
+}
+
+// parseOffsetPage reads the opaque cursor of the listings not ordered by id,
+// the base64 of how many items were returned so far.
+func parseOffsetPage(query url.Values) (database.Page, error) {
+	limit, cursor, err := parseCursor(query)
+	if err != nil {
+		return database.Page{}, err
+	}
+
+	page := database.Page{Limit: limit}
+	if cursor != "" {
+		if page.Offset, err = strconv.Atoi(cursor); err != nil || page.Offset < 0 {
+			return database.Page{}, fmt.Errorf("%w: cursor %q", InvalidParameterErr, query.Get("cursor"))
+		}
+	}
+
+	return page, nil
+}
+
+// parseCursor returns the limit, capped to maxLimit, and the decoded cursor.
+func parseCursor(query url.Values) (int, string, error) {
+	limit := defaultLimit
+	if value := query.Get("limit"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed <= 0 {
+			return 0, "", fmt.Errorf("%w: limit %q", InvalidParameterErr, value)
+		}
+
+		limit = min(parsed, maxLimit)
+	}
+
+	cursor := query.Get("cursor")
+	decoded, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, "", fmt.Errorf("%w: cursor %q", InvalidParameterErr, cursor)
+	}
+
+	return limit, string(decoded), nil
+}
+
+// newPage sets the cursor only when the page is full, as more items may
+// follow it.
+func newPage[T any](items []T, limit int, id func(T) uint) Page[T] {
+	output := Page[T]{Items: nonNil(items)}
+	if len(items) != 0 && len(items) == limit {
+		output.NextCursor = encodeCursor(strconv.FormatUint(uint64(id(items[len(items)-1])), 10))
+	}
+
+	return output
+}
+
+func newOffsetPage[T any](items []T, page database.Page) Page[T] {
+	output := Page[T]{Items: nonNil(items)}
+	if len(items) != 0 && len(items) == page.Limit {
+		output.NextCursor = encodeCursor(strconv.Itoa(page.Offset + len(items)))
+	}
+
+	return output
+}
+
+func encodeCursor(value string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(value))
+}
+
+func values(query url.Values, key string) []string {
+	var output []string
+	for _, value := range query[key] {
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				output = append(output, item)
+			}
+		}
+	}
+
+	return output
+}
+
+func parseIDs(values []string) ([]uint, error) {
+	output := make([]uint, 0, len(values))
+	for _, value := range values {
+		id, err := parseID(value)
+		if err != nil {
+			return nil, err
+		}
+
+		output = append(output, id)
+	}
+
+	return output, nil
+}
+
+func parseID(value string) (uint, error) {
+	parsed, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: id %q", InvalidParameterErr, value)
+	}
+
+	return uint(parsed), nil
+}
+
+// parseTime accepts RFC 3339 timestamps or plain dates.
+func parseTime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	for _, layout := range []string{time.RFC3339, time.DateOnly} {
+		if parsed, err := time.Parse(layout, value); err == nil {
+			return parsed, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("%w: time %q", InvalidParameterErr, value)
+}
+
+func nonNil[T any](items []T) []T {
+	if items == nil {
+		return []T{}
+	}
+
+	return items
+}
+
+func writeJSON(w http.ResponseWriter, statusCode int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func writeError(w http.ResponseWriter, statusCode int, err error) {
+	writeJSON(w, statusCode, Error{Error: err.Error()})
+}
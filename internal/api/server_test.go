@@ -0,0 +1,216 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"jira-integration/internal/database"
+	"jira-integration/pkg/analytics"
+	"jira-integration/pkg/issue"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+type (
+	memoryDatabase struct {
+		issues  []issue.Issue
+		sprints []issue.Sprint
+	}
+)
+
+func (m memoryDatabase) ListIssues(_ context.Context, filter database.IssueFilter, page database.Page) ([]issue.Issue, error) {
+	var output []issue.Issue
+	for _, i := range m.issues {
+		if i.ID <= page.After || (len(filter.Projects) != 0 && !slices.Contains(filter.Projects, i.Project)) {
+			continue
+		}
+
+		if len(output) == page.Limit {
+			break
+		}
+
+		output = append(output, i)
+	}
+
+	return output, nil
+}
+
+func (m memoryDatabase) GetIssueByKey(_ context.Context, key string) (issue.Issue, bool, error) {
+	for _, i := range m.issues {
+		if i.Key == key {
+			return i, true, nil
+		}
+	}
+
+	return issue.Issue{}, false, nil
+}
+
+func (m memoryDatabase) GetByKey(ctx context.Context, key string) (issue.Stamp, bool, error) {
+	i, exists, err := m.GetIssueByKey(ctx, key)
+	return i.Stamp, exists, err
+}
+
+func (m memoryDatabase) ListChangelog(_ context.Context, issueID uint, page database.Page) ([]issue.Changelog, error) {
+	for _, i := range m.issues {
+		if i.ID == issueID {
+			return offsetPage(i.Changelog, page), nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (m memoryDatabase) ListSprints(_ context.Context, _ database.SprintFilter, page database.Page) ([]issue.Sprint, error) {
+	return offsetPage(m.sprints, page), nil
+}
+
+func (m memoryDatabase) GetSprint(_ context.Context, sprintID uint) (issue.Sprint, bool, error) {
+	for _, s := range m.sprints {
+		if s.ID == sprintID {
+			return s, true, nil
+		}
+	}
+
+	return issue.Sprint{}, false, nil
+}
+
+func (m memoryDatabase) ListMetrics(_ context.Context, _ database.IssueFilter, _ database.Page) ([]analytics.WorkItem, error) {
+	return nil, nil
+}
+
+func offsetPage[T any](items []T, page database.Page) []T {
+	items = items[min(page.Offset, len(items)):]
+	return items[:min(page.Limit, len(items))]
+}
+
+func TestServer_ListIssues(t *testing.T) {
+	server := NewServer(memoryDatabase{issues: []issue.Issue{
+		{Stamp: issue.Stamp{ID: 1, Key: "A-1"}, Project: "A"},
+		{Stamp: issue.Stamp{ID: 2, Key: "B-1"}, Project: "B"},
+		{Stamp: issue.Stamp{ID: 3, Key: "A-2"}, Project: "A"},
+		{Stamp: issue.Stamp{ID: 4, Key: "A-3"}, Project: "A"},
+	}})
+
+	var keys []string
+	path := "/issues?project=A&limit=2"
+	for pages := 0; path != ""; pages++ {
+		if pages > 3 {
+			t.Fatal("pagination doesn't end")
+		}
+
+		response := httptest.NewRecorder()
+		server.ServeHTTP(response, httptest.NewRequest(http.MethodGet, path, nil))
+		if response.Code != http.StatusOK {
+			t.Fatalf("GET %s = %d, want %d", path, response.Code, http.StatusOK)
+		}
+
+		var page Page[issue.Issue]
+		if err := json.NewDecoder(response.Body).Decode(&page); err != nil {
+			t.Fatalf("decoding page: %v", err)
+		}
+
+		for _, i := range page.Items {
+			keys = append(keys, i.Key)
+		}
+
+		path = ""
+		if page.NextCursor != "" {
+			path = "/issues?project=A&limit=2&cursor=" + page.NextCursor
+		}
+	}
+
+	if want := []string{"A-1", "A-2", "A-3"}; !reflect.DeepEqual(keys, want) {
+		t.Errorf("keys = %v, want %v", keys, want)
+	}
+}
+
+func TestServer_Errors(t *testing.T) {
+	server := NewServer(memoryDatabase{})
+	tests := []struct {
+		path string
+		want int
+	}{
+		{path: "/issues/A-1", want: http.StatusNotFound},
+		{path: "/issues/A-1/changelog", want: http.StatusNotFound},
+		{path: "/sprints/10", want: http.StatusNotFound},
+		{path: "/sprints/abc", want: http.StatusBadRequest},
+		{path: "/issues?limit=-1", want: http.StatusBadRequest},
+		{path: "/issues?cursor=!", want: http.StatusBadRequest},
+		{path: "/sprints?limit=0", want: http.StatusBadRequest},
+		{path: "/sprints?cursor=YQ", want: http.StatusBadRequest},
+		{path: "/issues?updated_from=yesterday", want: http.StatusBadRequest},
+		{path: "/issues?updated_from=2024-03-01", want: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			response := httptest.NewRecorder()
+			server.ServeHTTP(response, httptest.NewRequest(http.MethodGet, tt.path, nil))
+			if response.Code != tt.want {
+				t.Errorf("GET %s = %d, want %d", tt.path, response.Code, tt.want)
+			}
+		})
+	}
+}
+
+func TestServer_OffsetPages(t *testing.T) {
+	var changelog []issue.Changelog
+	var sprints []issue.Sprint
+	for n := 1; n <= 5; n++ {
+		changelog = append(changelog, issue.Changelog{ID: uint(n)})
+		sprints = append(sprints, issue.Sprint{ID: uint(6 - n)})
+	}
+
+	server := NewServer(memoryDatabase{
+		issues:  []issue.Issue{{Stamp: issue.Stamp{ID: 1, Key: "A-1"}, Changelog: changelog}},
+		sprints: sprints,
+	})
+
+	tests := []struct {
+		path    string
+		wantIDs []uint
+	}{
+		{path: "/issues/A-1/changelog?limit=2", wantIDs: []uint{1, 2, 3, 4, 5}},
+		{path: "/sprints?limit=2", wantIDs: []uint{5, 4, 3, 2, 1}},
+		{path: "/sprints?limit=5", wantIDs: []uint{5, 4, 3, 2, 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			var ids []uint
+			path := tt.path
+			for pages := 0; path != ""; pages++ {
+				if pages > 5 {
+					t.Fatal("pagination doesn't end")
+				}
+
+				response := httptest.NewRecorder()
+				server.ServeHTTP(response, httptest.NewRequest(http.MethodGet, path, nil))
+				if response.Code != http.StatusOK {
+					t.Fatalf("GET %s = %d, want %d", path, response.Code, http.StatusOK)
+				}
+
+				var page Page[struct {
+					ID uint `json:"id"`
+				}]
+				if err := json.NewDecoder(response.Body).Decode(&page); err != nil {
+					t.Fatalf("decoding page: %v", err)
+				}
+
+				for _, item := range page.Items {
+					ids = append(ids, item.ID)
+				}
+
+				path = ""
+				if page.NextCursor != "" {
+					path = tt.path + "&cursor=" + page.NextCursor
+				}
+			}
+
+			if !reflect.DeepEqual(ids, tt.wantIDs) {
+				t.Errorf("ids = %v, want %v", ids, tt.wantIDs)
+			}
+		})
+	}
+}
@@ -0,0 +1,257 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	DefaultProfile       = "default"
+	DefaultSiteURL       = "https://bexs.atlassian.net"
+	DefaultHealthAddress = ":8080"
+	DefaultAPIAddress    = ":8090"
+
+	FetchJobType    = "fetch"
+	SprintsJobType  = "sprints"
+	VersionsJobType = "versions"
+	BoardsJobType   = "boards"
+	StatusesJobType = "statuses"
+)
+
+var (
+	InvalidConfigErr   = errors.New("invalid config")
+	ProfileNotFoundErr = errors.New("profile not found")
+)
+
+type (
+	Config struct {
+		Profile  string             `yaml:"profile"`
+		Profiles map[string]Profile `yaml:"profiles"`
+	}
+
+	Profile struct {
+		Name       string     `yaml:"-"`
+		Jira       Jira       `yaml:"jira"`
+		Database   Database   `yaml:"database"`
+		Analytics  Analytics  `yaml:"analytics"`
+		Daemon     Daemon     `yaml:"daemon"`
+		API        API        `yaml:"api"`
+		Prometheus Prometheus `yaml:"prometheus"`
+	}
+
+	// Jira tells how to reach the site. Archive keeps the raw body of every
+	// search page, issue, changelog page and sprint read, so the stored data
+	// can be rebuilt with the reprocess command without fetching it again.
+	Jira struct {
+		URL      string `yaml:"url"`
+		Username string `yaml:"username"`
+		Password string `yaml:"password"`
+		Boards   []uint `yaml:"boards"`
+		Archive  bool   `yaml:"archive"`
+	}
+
+	Database struct {
+		DSN         string `yaml:"dsn"`
+		AutoMigrate *bool  `yaml:"auto_migrate"`
+	}
+
+	StatusSet struct {
+		Statuses   []string `yaml:"statuses"`
+		Categories []string `yaml:"categories"`
+	}
+
+	// Analytics tells which statuses start and finish the work, and which
+	// are active work rather than waiting. Statuses match by name and
+	// categories by key (new, indeterminate, done); by default an issue
+	// starts and is active in the indeterminate category and finishes in done.
+	Analytics struct {
+		Start    StatusSet `yaml:"start"`
+		Done     StatusSet `yaml:"done"`
+		Active   StatusSet `yaml:"active"`
+		Calendar Calendar  `yaml:"calendar"`
+	}
+
+	// Calendar sets the working hours used for business time. Workday bounds
+	// are "15:04" in the time zone, holidays are "2006-01-02" dates.
+	Calendar struct {
+		Timezone     string   `yaml:"timezone"`
+		WorkdayStart string   `yaml:"workday_start"`
+		WorkdayEnd   string   `yaml:"workday_end"`
+		Weekdays     []string `yaml:"weekdays"`
+		Holidays     []string `yaml:"holidays"`
+	}
+
+	Daemon struct {
+		HealthAddress string `yaml:"health_address"`
+		Jobs          []Job  `yaml:"jobs"`
+	}
+
+	API struct {
+		Address string `yaml:"address"`
+	}
+
+	// Prometheus tells where one-shot commands push their metrics; the daemon
+	// and the API serve them on /prometheus instead.
+	Prometheus struct {
+		PushURL string `yaml:"push_url"`
+	}
+
+	Job struct {
+		Name     string   `yaml:"name"`
+		Schedule string   `yaml:"schedule"`
+		Type     string   `yaml:"type"`
+		JQL      string   `yaml:"jql,omitempty"`
+		States   []string `yaml:"states,omitempty"`
+		Boards   []uint   `yaml:"boards,omitempty"`
+		Projects []string `yaml:"projects,omitempty"`
+	}
+)
+
+// Load reads the configuration file at path and returns the selected profile
+// with environment overrides applied. A missing file is not an error, so the
+// CLI keeps working with environment variables only. The profile is chosen by
+// name, falling back to JIRA_PROFILE, the file's "profile" key and "default".
+func Load(path, profileName string) (Profile, error) {
+	var config Config
+	raw, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return Profile{}, err
+	}
+
+	if err == nil {
+		if err := yaml.Unmarshal(raw, &config); err != nil {
+			return Profile{}, fmt.Errorf("%w: %s: %v", InvalidConfigErr, path, err)
+		}
+	}
+
+	if profileName == "" {
+		profileName = os.Getenv("JIRA_PROFILE")
+	}
+
+	if profileName == "" {
+		profileName = config.Profile
+	}
+
+	if profileName == "" {
+		profileName = DefaultProfile
+	}
+
+	profile, exists := config.Profiles[profileName]
+	if !exists && (profileName != DefaultProfile || len(config.Profiles) != 0) {
+		return Profile{}, fmt.Errorf("%w: %s (available: %v)", ProfileNotFoundErr, profileName, config.ProfileNames())
+	}
+
+	profile.Name = profileName
+	profile.applyEnv()
+	profile.applyDefaults()
+	return profile, nil
+}
+
+func (c Config) ProfileNames() []string {
+	names := make([]string, 0, len(c.Profiles))
+	for name := range c.Profiles {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+	return names
+}
+
+func (p *Profile) applyEnv() {
+	overrides := map[string]*string{
+		"JIRA_URL":                 &p.Jira.URL,
+		"JIRA_USERNAME":            &p.Jira.Username,
+		"JIRA_PASSWORD":            &p.Jira.Password,
+		"JIRA_DB_DSN":              &p.Database.DSN,
+		"JIRA_PROMETHEUS_PUSH_URL": &p.Prometheus.PushURL,
+	}
+
+	for env, field := range overrides {
+		if value, ok := os.LookupEnv(env); ok && value != "" {
+			*field = value
+		}
+	}
+}
+
+func (p *Profile) applyDefaults() {
+	if p.Jira.URL == "" {
+		p.Jira.URL = DefaultSiteURL
+	}
+
+	if p.Database.AutoMigrate == nil {
+		autoMigrate := true
+		p.Database.AutoMigrate = &autoMigrate
+	}
+
+	if p.Daemon.HealthAddress == "" {
+		p.Daemon.HealthAddress = DefaultHealthAddress
+	}
+
+	if p.API.Address == "" {
+		p.API.Address = DefaultAPIAddress
+	}
+}
+
+func (p Profile) ValidateJira() error {
+	if p.Jira.Username == "" || p.Jira.Password == "" {
+		return fmt.Errorf("%w: profile %s: jira username and password are required", InvalidConfigErr, p.Name)
+	}
+
+	return nil
+}
+
+func (p Profile) ValidateDatabase() error {
+	if p.Database.DSN == "" {
+		return fmt.Errorf("%w: profile %s: database dsn is required", InvalidConfigErr, p.Name)
+	}
+
+	return nil
+}
+
+func (d Daemon) Validate() error {
+	if len(d.Jobs) == 0 {
+		return fmt.Errorf("%w: no jobs configured", InvalidConfigErr)
+	}
+
+	for _, j := range d.Jobs {
+		if err := j.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (j Job) Validate() error {
+	if j.Name == "" || j.Schedule == "" {
+		return fmt.Errorf("%w: jobs require name and schedule", InvalidConfigErr)
+	}
+
+	switch j.Type {
+	case FetchJobType:
+		if j.JQL == "" {
+			return fmt.Errorf("%w: job %s: jql is required", InvalidConfigErr, j.Name)
+		}
+	case SprintsJobType:
+		if len(j.States) == 0 && len(j.Boards) == 0 {
+			return fmt.Errorf("%w: job %s: states or boards are required", InvalidConfigErr, j.Name)
+		}
+	case BoardsJobType:
+		if len(j.Boards) == 0 && len(j.Projects) == 0 {
+			return fmt.Errorf("%w: job %s: boards or projects are required", InvalidConfigErr, j.Name)
+		}
+	case StatusesJobType:
+	case VersionsJobType:
+		if len(j.Projects) == 0 {
+			return fmt.Errorf("%w: job %s: projects are required", InvalidConfigErr, j.Name)
+		}
+	default:
+		return fmt.Errorf("%w: job %s: unknown type %q", InvalidConfigErr, j.Name, j.Type)
+	}
+
+	return nil
+}
@@ -0,0 +1,100 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const testConfig = `
+profile: team-a
+profiles:
+  team-a:
+    jira:
+      url: https://team-a.atlassian.net
+      username: a@example.com
+    database:
+      dsn: host=team-a
+  team-b:
+    jira:
+      url: https://team-b.atlassian.net
+    database:
+      dsn: host=team-b
+      auto_migrate: false
+`
+
+func TestLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(testConfig), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name            string
+		path            string
+		profile         string
+		env             map[string]string
+		wantURL         string
+		wantDSN         string
+		wantAutoMigrate bool
+		wantErr         error
+	}{
+		{
+			name:            "use the profile selected in the file",
+			path:            path,
+			wantURL:         "https://team-a.atlassian.net",
+			wantDSN:         "host=team-a",
+			wantAutoMigrate: true,
+		},
+		{
+			name:            "prefer the profile given by argument",
+			path:            path,
+			profile:         "team-b",
+			env:             map[string]string{"JIRA_PROFILE": "team-a"},
+			wantURL:         "https://team-b.atlassian.net",
+			wantDSN:         "host=team-b",
+			wantAutoMigrate: false,
+		},
+		{
+			name:            "override profile values with the environment",
+			path:            path,
+			env:             map[string]string{"JIRA_DB_DSN": "host=env"},
+			wantURL:         "https://team-a.atlassian.net",
+			wantDSN:         "host=env",
+			wantAutoMigrate: true,
+		},
+		{
+			name:            "fall back to the environment when there is no file",
+			path:            filepath.Join(t.TempDir(), "missing.yaml"),
+			env:             map[string]string{"JIRA_DB_DSN": "host=env"},
+			wantURL:         DefaultSiteURL,
+			wantDSN:         "host=env",
+			wantAutoMigrate: true,
+		},
+		{
+			name:    "fail on unknown profiles",
+			path:    path,
+			profile: "team-c",
+			wantErr: ProfileNotFoundErr,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, env := range []string{"JIRA_PROFILE", "JIRA_URL", "JIRA_USERNAME", "JIRA_PASSWORD", "JIRA_DB_DSN"} {
+				t.Setenv(env, tt.env[env])
+			}
+
+			got, err := Load(tt.path, tt.profile)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Load() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if got.Jira.URL != tt.wantURL || got.Database.DSN != tt.wantDSN || *got.Database.AutoMigrate != tt.wantAutoMigrate {
+				t.Errorf("Load() = %+v, want url %s, dsn %s and auto migrate %v", got, tt.wantURL, tt.wantDSN, tt.wantAutoMigrate)
+			}
+		})
+	}
+}
@@ -0,0 +1,142 @@
+package database
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// MaxIssueIDs caps the issue ids a filter takes, such as the ones matched by
+// a JQL query, so a broad query fails early instead of scanning huge arrays.
+const MaxIssueIDs = 100_000
+
+var (
+	TooManyIssueIDsErr = errors.New("too many issue ids")
+)
+
+type (
+	// IssueFilter narrows queries over the issues table. Zero values don't
+	// filter anything.
+	IssueFilter struct {
+		IssueIDs    []uint
+		Projects    []string
+		IssueTypes  []string
+		Statuses    []string
+		Sprints     []uint
+		UpdatedFrom time.Time
+		UpdatedTo   time.Time
+	}
+
+	// Page is a keyset page over ids: the rows after the given id, up to
+	// limit of them. Listings not ordered by id skip Offset rows instead.
+	// Zero values don't paginate.
+	Page struct {
+		After  uint
+		Offset int
+		Limit  int
+	}
+
+	SprintFilter struct {
+		States []string
+		Boards []uint
+	}
+
+	// idArray binds the ids as a single Postgres array, so the statement
+	// takes one parameter however many ids there are.
+	idArray []uint
+
+	// IssueScope iterates over the issues matching a filter.
+	IssueScope struct {
+		db     *gorm.DB
+		filter IssueFilter
+	}
+)
+
+// Validate tells whether the filter can be queried.
+func (f IssueFilter) Validate() error {
+	if len(f.IssueIDs) > MaxIssueIDs {
+		return fmt.Errorf("%w: %d, up to %d are allowed", TooManyIssueIDsErr, len(f.IssueIDs), MaxIssueIDs)
+	}
+
+	return nil
+}
+
+func (f IssueFilter) apply(db *gorm.DB) *gorm.DB {
+	if len(f.IssueIDs) != 0 {
+		db = db.Where("issues.id = any(?::bigint[])", idArray(f.IssueIDs))
+	}
+
+	if len(f.Projects) != 0 {
+		db = db.Where("issues.project in (?)", f.Projects)
+	}
+
+	if len(f.IssueTypes) != 0 {
+		db = db.Where("issues.issue_type in (?)", f.IssueTypes)
+	}
+
+	if len(f.Statuses) != 0 {
+		db = db.Where("issues.status in (?)", f.Statuses)
+	}
+
+	if len(f.Sprints) != 0 {
+		db = db.Where("issues.sprint_id in (?)", f.Sprints)
+	}
+
+	if !f.UpdatedFrom.IsZero() {
+		db = db.Where("issues.updated_at >= ?", f.UpdatedFrom)
+	}
+
+	if !f.UpdatedTo.IsZero() {
+		db = db.Where("issues.updated_at < ?", f.UpdatedTo)
+	}
+
+	return db
+}
+
+func (p Page) apply(db *gorm.DB, column string) *gorm.DB {
+	if p.After != 0 {
+		db = db.Where(column+" > ?", p.After)
+	}
+
+	if p.Offset > 0 {
+		db = db.Offset(p.Offset)
+	}
+
+	if p.Limit > 0 {
+		db = db.Limit(p.Limit)
+	}
+
+	return db
+}
+
+func (f SprintFilter) apply(db *gorm.DB) *gorm.DB {
+	if len(f.States) != 0 {
+		db = db.Where("state in (?)", f.States)
+	}
+
+	if len(f.Boards) != 0 {
+		db = db.Where("board_id in (?)", f.Boards)
+	}
+
+	return db
+}
+
+func (a idArray) Value() (driver.Value, error) {
+	var output strings.Builder
+	output.WriteByte('{')
+	for i, id := range a {
+		if i != 0 {
+			output.WriteByte(',')
+		}
+
+		output.WriteString(strconv.FormatUint(uint64(id), 10))
+	}
+
+	output.WriteByte('}')
+	return output.String(), nil
+}
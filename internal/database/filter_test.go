@@ -0,0 +1,75 @@
+package database
+
+import (
+	"errors"
+	"jira-integration/internal/database/model"
+	"strings"
+	"testing"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func TestIssueFilter_apply(t *testing.T) {
+	conn, err := gorm.Open(postgres.Open("host=localhost"), &gorm.Config{DryRun: true, DisableAutomaticPing: true})
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+
+	ids := make([]uint, 70_000)
+	for i := range ids {
+		ids[i] = uint(i + 1)
+	}
+
+	tests := []struct {
+		name     string
+		filter   IssueFilter
+		wantSQL  string
+		wantVars []string
+	}{
+		{
+			name:     "bind the issue ids as a single array",
+			filter:   IssueFilter{IssueIDs: []uint{3, 1, 2}},
+			wantSQL:  "issues.id = any($1::bigint[])",
+			wantVars: []string{"{3,1,2}"},
+		},
+		{
+			name:    "bind more ids than postgres parameters",
+			filter:  IssueFilter{IssueIDs: ids, Projects: []string{"PAY"}},
+			wantSQL: "issues.id = any($1::bigint[]) AND issues.project in ($2)",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			statement := tt.filter.apply(conn.Model(&model.Issue{})).Find(&[]model.Issue{}).Statement
+			if got := statement.SQL.String(); !strings.Contains(got, tt.wantSQL) {
+				t.Errorf("apply() sql = %s, want %s", got, tt.wantSQL)
+			}
+
+			for i, want := range tt.wantVars {
+				if got, _ := statement.Vars[i].(idArray).Value(); got != want {
+					t.Errorf("apply() var %d = %v, want %s", i, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestIssueFilter_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		ids     int
+		wantErr error
+	}{
+		{name: "accept up to the maximum", ids: MaxIssueIDs},
+		{name: "reject more than the maximum", ids: MaxIssueIDs + 1, wantErr: TooManyIssueIDsErr},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter := IssueFilter{IssueIDs: make([]uint, tt.ids)}
+			if err := filter.Validate(); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
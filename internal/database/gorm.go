@@ -1,39 +1,116 @@
 package database
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
 	"errors"
+	"fmt"
+	"io"
 	"jira-integration/internal/database/model"
+	"jira-integration/pkg/analytics"
 	"jira-integration/pkg/issue"
-	"log"
+	"jira-integration/pkg/job"
+	"jira-integration/pkg/logging"
+	"jira-integration/pkg/tracing"
+	"strconv"
+	"time"
 
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	tracer = otel.Tracer("jira-integration/internal/database")
 )
 
 type (
 	Gorm struct {
 		db *gorm.DB
 	}
+
+	Stats struct {
+		Issues        int64
+		Changelogs    int64
+		Sprints       int64
+		Versions      int64
+		LastUpdatedAt time.Time
+	}
 )
 
 func NewGorm(db *gorm.DB) *Gorm {
-	if err := db.AutoMigrate(
+	return &Gorm{
+		db: db,
+	}
+}
+
+func (g Gorm) Migrate(ctx context.Context) error {
+	if err := g.db.WithContext(ctx).AutoMigrate(
 		&model.Label{},
 		&model.Product{},
 		&model.Changelog{},
 		&model.Sprint{},
+		&model.Board{},
+		&model.BoardColumn{},
+		&model.BoardColumnStatus{},
 		&model.Account{},
 		&model.Issue{},
+		&model.Version{},
+		&model.Status{},
+		&model.IssueMetric{},
+		&model.IssueStatusDuration{},
+		&model.Run{},
+		&model.RawPayload{},
 	); err != nil {
-		log.Fatalln("while running auto migrate", err)
+		return fmt.Errorf("while running auto migrate: %w", err)
 	}
 
-	return &Gorm{
-		db: db,
+	if err := g.migrateChangelogKey(ctx); err != nil {
+		return fmt.Errorf("while migrating changelog primary key: %w", err)
+	}
+
+	return nil
+}
+
+// migrateChangelogKey widens the changelogs primary key of databases created
+// when only status transitions were stored, since a single history entry can
+// now change the status, the sprint and the story points at once.
+func (g Gorm) migrateChangelogKey(ctx context.Context) error {
+	var columns int64
+	if err := g.db.WithContext(ctx).Raw(`select count(*)
+		from information_schema.key_column_usage
+		where table_name = 'changelogs' and constraint_name = 'changelogs_pkey'`).Scan(&columns).Error; err != nil {
+		return err
+	}
+
+	if columns != 1 {
+		return nil
 	}
+
+	err := g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("alter table changelogs drop constraint changelogs_pkey").Error; err != nil {
+			return err
+		}
+
+		return tx.Exec("alter table changelogs add primary key (id, field)").Error
+	})
+	if err != nil {
+		return err
+	}
+
+	// The stored issues are skipped by fetch while Jira doesn't update them,
+	// so their sprint, story points and flag history would never be filled.
+	logging.FromContext(ctx).Warn("changelog now tracks sprint, story points and flag changes, run fetch -force to backfill the stored issues")
+	return nil
 }
 
-func (g Gorm) CreateIssue(ctx context.Context, i issue.Issue) error {
+func (g Gorm) CreateIssue(ctx context.Context, i issue.Issue) (err error) {
+	ctx, span := startIssueSpan(ctx, "Gorm.CreateIssue", i)
+	defer tracing.End(span, &err)
+
 	m := model.NewIssue(i)
 	if err := g.db.WithContext(ctx).Create(m).Error; err != nil {
 		return err
@@ -42,7 +119,10 @@ func (g Gorm) CreateIssue(ctx context.Context, i issue.Issue) error {
 	return nil
 }
 
-func (g Gorm) UpdateIssue(ctx context.Context, i issue.Issue) error {
+func (g Gorm) UpdateIssue(ctx context.Context, i issue.Issue) (err error) {
+	ctx, span := startIssueSpan(ctx, "Gorm.UpdateIssue", i)
+	defer tracing.End(span, &err)
+
 	m := model.NewIssue(i)
 	if err := g.db.WithContext(ctx).Save(m).Error; err != nil {
 		return err
@@ -69,6 +149,265 @@ func (g Gorm) GetByID(ctx context.Context, issueID uint) (issue.Stamp, bool, err
 	}, true, nil
 }
 
+// ListIssues returns a page of the issues matching the filter, ordered by id,
+// with their associations but not their changelog.
+func (g Gorm) ListIssues(ctx context.Context, filter IssueFilter, page Page) ([]issue.Issue, error) {
+	var issues []model.Issue
+	query := preloadIssueDetails(g.db.WithContext(ctx)).Order("issues.id")
+	if err := page.apply(filter.apply(query), "issues.id").Find(&issues).Error; err != nil {
+		return nil, err
+	}
+
+	output := make([]issue.Issue, len(issues), len(issues))
+	for i, m := range issues {
+		output[i] = m.ToDomain()
+	}
+
+	return output, nil
+}
+
+// GetIssueByKey returns the issue with its associations and changelog.
+func (g Gorm) GetIssueByKey(ctx context.Context, key string) (issue.Issue, bool, error) {
+	m := &model.Issue{}
+	query := preloadIssueDetails(g.db.WithContext(ctx)).Preload("Changelog", func(db *gorm.DB) *gorm.DB {
+		return db.Order("created_at, id")
+	})
+
+	if err := query.First(m, "key = ?", key).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return issue.Issue{}, false, nil
+		}
+
+		return issue.Issue{}, false, err
+	}
+
+	return m.ToDomain(), true, nil
+}
+
+func (g Gorm) GetByKey(ctx context.Context, key string) (issue.Stamp, bool, error) {
+	m := &model.Issue{}
+	if err := g.db.WithContext(ctx).Select("id, key, created_at, updated_at").First(m, "key = ?", key).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return issue.Stamp{}, false, nil
+		}
+
+		return issue.Stamp{}, false, err
+	}
+
+	return issue.Stamp{
+		ID:        m.ID,
+		Key:       m.Key,
+		CreatedAt: m.CreatedAt,
+		UpdatedAt: m.UpdatedAt,
+	}, true, nil
+}
+
+// ListChangelog returns a page of the tracked changes of the issue, oldest
+// first.
+func (g Gorm) ListChangelog(ctx context.Context, issueID uint, page Page) ([]issue.Changelog, error) {
+	var changelog []model.Changelog
+	query := g.db.WithContext(ctx).Where("issue_id = ?", issueID).Order("created_at, id, field")
+	if err := page.apply(query, "id").Find(&changelog).Error; err != nil {
+		return nil, err
+	}
+
+	output := make([]issue.Changelog, len(changelog), len(changelog))
+	for i, c := range changelog {
+		output[i] = c.ToDomain()
+	}
+
+	return output, nil
+}
+
+// ListChangelogs returns a page of the issues matching the filter, ordered by
+// id, with only their stamp and changelog.
+func (g Gorm) ListChangelogs(ctx context.Context, filter IssueFilter, page Page) ([]issue.Issue, error) {
+	var issues []model.Issue
+	query := g.db.WithContext(ctx).
+		Select("issues.id, issues.key, issues.created_at, issues.updated_at").
+		Preload("Changelog", func(db *gorm.DB) *gorm.DB {
+			return db.Order("created_at, id")
+		}).
+		Order("issues.id")
+
+	if err := page.apply(filter.apply(query), "issues.id").Find(&issues).Error; err != nil {
+		return nil, err
+	}
+
+	output := make([]issue.Issue, len(issues), len(issues))
+	for i, m := range issues {
+		output[i] = issue.Issue{
+			Stamp: issue.Stamp{
+				ID:        m.ID,
+				Key:       m.Key,
+				CreatedAt: m.CreatedAt,
+				UpdatedAt: m.UpdatedAt,
+			},
+			Changelog: m.ToDomain().Changelog,
+		}
+	}
+
+	return output, nil
+}
+
+// EachIssue walks through every stored issue with its changelog in batches,
+// keeping memory flat regardless of how many issues there are.
+func (g Gorm) EachIssue(ctx context.Context, batchSize int, fn func(ctx context.Context, issues []issue.Issue) error) error {
+	return g.Scope(IssueFilter{}).EachIssue(ctx, batchSize, fn)
+}
+
+// Scope narrows EachIssue to the issues matching the filter.
+func (g Gorm) Scope(filter IssueFilter) IssueScope {
+	return IssueScope{db: g.db, filter: filter}
+}
+
+func (s IssueScope) EachIssue(ctx context.Context, batchSize int, fn func(ctx context.Context, issues []issue.Issue) error) error {
+	var batch []model.Issue
+	return s.filter.apply(s.db.WithContext(ctx)).Preload("Changelog").FindInBatches(&batch, batchSize, func(tx *gorm.DB, _ int) error {
+		issues := make([]issue.Issue, len(batch), len(batch))
+		for i, m := range batch {
+			issues[i] = m.ToDomain()
+		}
+
+		return fn(ctx, issues)
+	}).Error
+}
+
+// ListDoneDates returns when each issue in scope was last done, according to
+// the computed metrics, within [from, to).
+func (s IssueScope) ListDoneDates(ctx context.Context, from, to time.Time) ([]time.Time, error) {
+	var output []time.Time
+	query := s.db.WithContext(ctx).
+		Model(&model.IssueMetric{}).
+		Joins("inner join issues on issues.id = issue_metrics.issue_id").
+		Where("issue_metrics.done_at >= ? and issue_metrics.done_at < ?", from, to).
+		Order("issue_metrics.done_at")
+
+	if err := s.filter.apply(query).Pluck("issue_metrics.done_at", &output).Error; err != nil {
+		return nil, err
+	}
+
+	return output, nil
+}
+
+// ListInProgress returns the issues in scope that started but aren't done,
+// according to the computed metrics.
+func (s IssueScope) ListInProgress(ctx context.Context) ([]analytics.WorkItem, error) {
+	return s.listWorkItems(ctx, Page{}, "issue_metrics.started_at is not null and issue_metrics.done_at is null")
+}
+
+// ListWorkItems returns every issue in scope with its metrics, if computed.
+func (s IssueScope) ListWorkItems(ctx context.Context) ([]analytics.WorkItem, error) {
+	return s.listWorkItems(ctx, Page{}, "")
+}
+
+// ListMetrics returns a page of the issues matching the filter with their
+// computed metrics, ordered by id.
+func (g Gorm) ListMetrics(ctx context.Context, filter IssueFilter, page Page) ([]analytics.WorkItem, error) {
+	return g.Scope(filter).listWorkItems(ctx, page, "")
+}
+
+// ListDone returns the issues in scope done within [from, to), with their
+// cycle time.
+func (s IssueScope) ListDone(ctx context.Context, from, to time.Time) ([]analytics.WorkItem, error) {
+	return s.listWorkItems(ctx, Page{}, "issue_metrics.done_at >= ? and issue_metrics.done_at < ?", from, to)
+}
+
+func (s IssueScope) listWorkItems(ctx context.Context, page Page, condition string, args ...any) ([]analytics.WorkItem, error) {
+	var rows []model.WorkItemRow
+	query := s.db.WithContext(ctx).
+		Model(&model.Issue{}).
+		Select(`issues.id as issue_id, issues.key, issues.summary, issues.project, issues.issue_type,
+			issues.status, issues.status_category, issues.story_points, accounts.display_name as assignee,
+			parents.key as parent_key, issues.sprint_id, sprints.name as sprint_name,
+			issue_metrics.started_at, issue_metrics.done_at, issue_metrics.cycle_time_seconds,
+			issue_metrics.active_seconds, issue_metrics.waiting_seconds, issue_metrics.blocked_seconds`).
+		Joins("left join issue_metrics on issue_metrics.issue_id = issues.id").
+		Joins("left join accounts on accounts.id = issues.assignee_id").
+		Joins("left join issues parents on parents.id = issues.parent_id").
+		Joins("left join sprints on sprints.id = issues.sprint_id").
+		Order("issues.id")
+
+	if condition != "" {
+		query = query.Where(condition, args...)
+	}
+
+	if err := page.apply(s.filter.apply(query), "issues.id").Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	output := make([]analytics.WorkItem, len(rows), len(rows))
+	for i, row := range rows {
+		output[i] = row.ToDomain()
+	}
+
+	return output, nil
+}
+
+// CountOpenChildren counts the children of the parent issue that aren't
+// done according to the computed metrics.
+func (g Gorm) CountOpenChildren(ctx context.Context, parentKey string) (int, bool, error) {
+	parent := &model.Issue{}
+	if err := g.db.WithContext(ctx).Select("id").First(parent, "key = ?", parentKey).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, false, nil
+		}
+
+		return 0, false, err
+	}
+
+	var count int64
+	err := g.db.WithContext(ctx).
+		Model(&model.Issue{}).
+		Joins("left join issue_metrics on issue_metrics.issue_id = issues.id").
+		Where("issues.parent_id = ? and issue_metrics.done_at is null", parent.ID).
+		Count(&count).Error
+
+	return int(count), true, err
+}
+
+func (g Gorm) SaveMetrics(ctx context.Context, metrics analytics.Metrics) (err error) {
+	ctx, span := tracer.Start(ctx, "Gorm.SaveMetrics", trace.WithAttributes(attribute.Int64("jira.issue.id", int64(metrics.IssueID))))
+	defer tracing.End(span, &err)
+
+	metric, durations := model.NewIssueMetric(metrics)
+	return g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(metric).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Delete(&model.IssueStatusDuration{}, "issue_id = ?", metric.IssueID).Error; err != nil {
+			return err
+		}
+
+		if len(durations) == 0 {
+			return nil
+		}
+
+		return tx.Create(&durations).Error
+	})
+}
+
+func (g Gorm) ListStatusDurations(ctx context.Context, filter IssueFilter) ([]analytics.IssueStatusDuration, error) {
+	var rows []model.IssueStatusDurationRow
+	query := g.db.WithContext(ctx).
+		Model(&model.IssueStatusDuration{}).
+		Select("issue_status_durations.*, issues.key as issue_key, issues.project, issues.issue_type").
+		Joins("inner join issues on issues.id = issue_status_durations.issue_id").
+		Order("issues.id, issue_status_durations.status")
+
+	if err := filter.apply(query).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	output := make([]analytics.IssueStatusDuration, len(rows), len(rows))
+	for i, row := range rows {
+		output[i] = row.ToDomain()
+	}
+
+	return output, nil
+}
+
 func (g Gorm) GetSprintsByState(ctx context.Context, states []string) ([]issue.Sprint, error) {
 	var sprints []model.Sprint
 	if err := g.db.WithContext(ctx).Where("state in (?)", states).Find(&sprints).Error; err != nil {
@@ -78,7 +417,80 @@ func (g Gorm) GetSprintsByState(ctx context.Context, states []string) ([]issue.S
 	return model.Sprints(sprints).ToDomain(), nil
 }
 
-func (g Gorm) SaveSprint(ctx context.Context, sprint issue.Sprint) error {
+// ListSprints returns a page of the sprints matching the filter, the latest
+// first.
+func (g Gorm) ListSprints(ctx context.Context, filter SprintFilter, page Page) ([]issue.Sprint, error) {
+	var sprints model.Sprints
+	query := filter.apply(g.db.WithContext(ctx)).Order("started_at desc nulls last, id")
+	if err := page.apply(query, "id").Find(&sprints).Error; err != nil {
+		return nil, err
+	}
+
+	return sprints.ToDomain(), nil
+}
+
+func (g Gorm) GetSprint(ctx context.Context, sprintID uint) (issue.Sprint, bool, error) {
+	m := &model.Sprint{}
+	if err := g.db.WithContext(ctx).First(m, "id = ?", sprintID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return issue.Sprint{}, false, nil
+		}
+
+		return issue.Sprint{}, false, err
+	}
+
+	return m.ToDomain(), true, nil
+}
+
+// ListBoardSprints returns the latest sprints of the board, most recently
+// started first.
+func (g Gorm) ListBoardSprints(ctx context.Context, boardID uint, states []string, limit int) ([]issue.Sprint, error) {
+	var sprints model.Sprints
+	query := g.db.WithContext(ctx).Where("board_id = ?", boardID).Order("started_at desc")
+	if len(states) != 0 {
+		query = query.Where("state in (?)", states)
+	}
+
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+
+	if err := query.Find(&sprints).Error; err != nil {
+		return nil, err
+	}
+
+	return sprints.ToDomain(), nil
+}
+
+// ListSprintIssues returns every issue that is or was part of the sprint,
+// with its changelog, so the sprint can be rebuilt at any moment.
+func (g Gorm) ListSprintIssues(ctx context.Context, sprintID uint) ([]issue.Issue, error) {
+	var issues []model.Issue
+	id := strconv.FormatUint(uint64(sprintID), 10)
+	if err := g.db.WithContext(ctx).
+		Preload("Changelog").
+		Preload("Sprint").
+		Where(`issues.sprint_id = ? or exists (
+			select 1 from changelogs
+			where changelogs.issue_id = issues.id and changelogs.field = ? and (
+				? = any(string_to_array(replace(changelogs.from_id, ' ', ''), ',')) or
+				? = any(string_to_array(replace(changelogs.to_id, ' ', ''), ','))))`, sprintID, issue.FieldSprint, id, id).
+		Find(&issues).Error; err != nil {
+		return nil, err
+	}
+
+	output := make([]issue.Issue, len(issues), len(issues))
+	for i, m := range issues {
+		output[i] = m.ToDomain()
+	}
+
+	return output, nil
+}
+
+func (g Gorm) SaveSprint(ctx context.Context, sprint issue.Sprint) (err error) {
+	ctx, span := tracer.Start(ctx, "Gorm.SaveSprint", trace.WithAttributes(attribute.Int64("jira.sprint.id", int64(sprint.ID))))
+	defer tracing.End(span, &err)
+
 	m := model.NewSprint(&sprint)
 	if err := g.db.WithContext(ctx).Save(m).Error; err != nil {
 		return err
@@ -86,3 +498,278 @@ func (g Gorm) SaveSprint(ctx context.Context, sprint issue.Sprint) error {
 
 	return nil
 }
+
+func (g Gorm) SaveBoard(ctx context.Context, board issue.Board) (err error) {
+	ctx, span := tracer.Start(ctx, "Gorm.SaveBoard", trace.WithAttributes(attribute.Int64("jira.board.id", int64(board.ID))))
+	defer tracing.End(span, &err)
+
+	m := model.NewBoard(board)
+	if err := g.db.WithContext(ctx).Save(m).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// SaveBoardColumns replaces the column mapping of the board, so statuses
+// moved between columns or removed from the board don't linger.
+func (g Gorm) SaveBoardColumns(ctx context.Context, boardID uint, columns []issue.BoardColumn) (err error) {
+	ctx, span := tracer.Start(ctx, "Gorm.SaveBoardColumns", trace.WithAttributes(attribute.Int64("jira.board.id", int64(boardID))))
+	defer tracing.End(span, &err)
+
+	boardColumns, boardColumnStatuses := model.NewBoardColumns(boardID, columns)
+	return g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&model.BoardColumnStatus{}, "board_id = ?", boardID).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Delete(&model.BoardColumn{}, "board_id = ?", boardID).Error; err != nil {
+			return err
+		}
+
+		if len(boardColumns) != 0 {
+			if err := tx.Create(&boardColumns).Error; err != nil {
+				return err
+			}
+		}
+
+		if len(boardColumnStatuses) != 0 {
+			if err := tx.Create(&boardColumnStatuses).Error; err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func (g Gorm) SaveStatus(ctx context.Context, status issue.Status) (err error) {
+	ctx, span := tracer.Start(ctx, "Gorm.SaveStatus", trace.WithAttributes(attribute.String("jira.status.id", status.ID)))
+	defer tracing.End(span, &err)
+
+	m := model.NewStatus(status)
+	if err := g.db.WithContext(ctx).Save(m).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (g Gorm) GetStatuses(ctx context.Context) (issue.Statuses, error) {
+	var statuses model.Statuses
+	if err := g.db.WithContext(ctx).Find(&statuses).Error; err != nil {
+		return nil, err
+	}
+
+	return statuses.ToDomain(), nil
+}
+
+// UpdateChangelogCategories fills the status categories of the transitions
+// stored before their statuses were known by the catalog.
+func (g Gorm) UpdateChangelogCategories(ctx context.Context) error {
+	db := g.db.WithContext(ctx)
+	if err := db.Exec(`update changelogs
+		set from_category = statuses.category_key
+		from statuses
+		where statuses.id = changelogs.from_id and coalesce(changelogs.from_category, '') = ''`).Error; err != nil {
+		return err
+	}
+
+	return db.Exec(`update changelogs
+		set to_category = statuses.category_key
+		from statuses
+		where statuses.id = changelogs.to_id and coalesce(changelogs.to_category, '') = ''`).Error
+}
+
+func (g Gorm) SaveVersion(ctx context.Context, version issue.Version) (err error) {
+	ctx, span := tracer.Start(ctx, "Gorm.SaveVersion", trace.WithAttributes(attribute.Int64("jira.version.id", int64(version.ID))))
+	defer tracing.End(span, &err)
+
+	m := model.NewVersion(version)
+	if err := g.db.WithContext(ctx).Save(m).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// SavePayload archives the raw body of a Jira response, replacing the one
+// previously saved for the same request.
+func (g Gorm) SavePayload(ctx context.Context, kind, key, page string, body []byte) (err error) {
+	ctx, span := tracer.Start(ctx, "Gorm.SavePayload", trace.WithAttributes(
+		attribute.String("payload.kind", kind),
+		attribute.String("payload.key", key),
+		attribute.Int("payload.size", len(body)),
+	))
+	defer tracing.End(span, &err)
+
+	var compressed bytes.Buffer
+	writer := gzip.NewWriter(&compressed)
+	if _, err := writer.Write(body); err != nil {
+		return err
+	}
+
+	if err := writer.Close(); err != nil {
+		return err
+	}
+
+	m := &model.RawPayload{
+		Kind:      kind,
+		Key:       key,
+		Page:      page,
+		Body:      compressed.Bytes(),
+		FetchedAt: time.Now(),
+	}
+
+	return g.db.WithContext(ctx).Clauses(clause.OnConflict{UpdateAll: true}).Create(m).Error
+}
+
+func (g Gorm) GetPayload(ctx context.Context, kind, key, page string) ([]byte, bool, error) {
+	m := &model.RawPayload{}
+	if err := g.db.WithContext(ctx).First(m, "kind = ? and key = ? and page = ?", kind, key, page).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, false, nil
+		}
+
+		return nil, false, err
+	}
+
+	reader, err := gzip.NewReader(bytes.NewReader(m.Body))
+	if err != nil {
+		return nil, false, fmt.Errorf("while decompressing %s %s: %w", kind, key, err)
+	}
+
+	body, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, false, fmt.Errorf("while decompressing %s %s: %w", kind, key, err)
+	}
+
+	return body, true, nil
+}
+
+// EachPayloadKey walks through the keys of the archived payloads of a kind in
+// batches, counting each request once regardless of how many pages it has.
+func (g Gorm) EachPayloadKey(ctx context.Context, kind string, batchSize int, fn func(ctx context.Context, keys []string) error) error {
+	after := ""
+	for {
+		var keys []string
+		if err := g.db.WithContext(ctx).
+			Model(&model.RawPayload{}).
+			Where("kind = ? and page = '' and key > ?", kind, after).
+			Order("key").
+			Limit(batchSize).
+			Pluck("key", &keys).Error; err != nil {
+			return err
+		}
+
+		if len(keys) == 0 {
+			return nil
+		}
+
+		if err := fn(ctx, keys); err != nil {
+			return err
+		}
+
+		if len(keys) < batchSize {
+			return nil
+		}
+
+		after = keys[len(keys)-1]
+	}
+}
+
+func (g Gorm) CreateRun(ctx context.Context, run job.Run) (_ job.Run, err error) {
+	ctx, span := tracer.Start(ctx, "Gorm.CreateRun", trace.WithAttributes(attribute.String("job.name", run.Job)))
+	defer tracing.End(span, &err)
+
+	m := model.NewRun(run)
+	if err := g.db.WithContext(ctx).Create(m).Error; err != nil {
+		return job.Run{}, err
+	}
+
+	return m.ToDomain(), nil
+}
+
+func (g Gorm) UpdateRun(ctx context.Context, run job.Run) (err error) {
+	ctx, span := tracer.Start(ctx, "Gorm.UpdateRun", trace.WithAttributes(attribute.String("job.name", run.Job)))
+	defer tracing.End(span, &err)
+
+	m := model.NewRun(run)
+	if err := g.db.WithContext(ctx).Save(m).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (g Gorm) GetLastRun(ctx context.Context, jobName string) (job.Run, bool, error) {
+	m := &model.Run{}
+	if err := g.db.WithContext(ctx).Order("started_at desc").First(m, "job = ?", jobName).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return job.Run{}, false, nil
+		}
+
+		return job.Run{}, false, err
+	}
+
+	return m.ToDomain(), true, nil
+}
+
+func (g Gorm) Ping(ctx context.Context) error {
+	conn, err := g.db.WithContext(ctx).DB()
+	if err != nil {
+		return err
+	}
+
+	return conn.PingContext(ctx)
+}
+
+func (g Gorm) GetStats(ctx context.Context) (Stats, error) {
+	var stats Stats
+	db := g.db.WithContext(ctx)
+	if err := db.Model(&model.Issue{}).Count(&stats.Issues).Error; err != nil {
+		return Stats{}, err
+	}
+
+	if err := db.Model(&model.Changelog{}).Count(&stats.Changelogs).Error; err != nil {
+		return Stats{}, err
+	}
+
+	if err := db.Model(&model.Sprint{}).Count(&stats.Sprints).Error; err != nil {
+		return Stats{}, err
+	}
+
+	if err := db.Model(&model.Version{}).Count(&stats.Versions).Error; err != nil {
+		return Stats{}, err
+	}
+
+	var lastUpdatedAt *time.Time
+	if err := db.Model(&model.Issue{}).Select("max(updated_at)").Scan(&lastUpdatedAt).Error; err != nil {
+		return Stats{}, err
+	}
+
+	if lastUpdatedAt != nil {
+		stats.LastUpdatedAt = *lastUpdatedAt
+	}
+
+	return stats, nil
+}
+
+func startIssueSpan(ctx context.Context, name string, i issue.Issue) (context.Context, trace.Span) {
+	return tracer.Start(ctx, name, trace.WithAttributes(
+		attribute.Int64("jira.issue.id", int64(i.ID)),
+		attribute.String("jira.issue.key", i.Key),
+		attribute.Int("jira.histories", len(i.Changelog)),
+	))
+}
+
+// preloadIssueDetails loads what model.NewIssue writes besides the changelog.
+func preloadIssueDetails(db *gorm.DB) *gorm.DB {
+	return db.
+		Preload("Parent").
+		Preload("Sprint").
+		Preload("Labels").
+		Preload("Products").
+		Preload("Assignee").
+		Preload("Reporter")
+}
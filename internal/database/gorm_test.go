@@ -0,0 +1,99 @@
+package database
+
+import (
+	"context"
+	"jira-integration/pkg/issue"
+	"os"
+	"reflect"
+	"testing"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// testDSNEnv points the database tests to a disposable Postgres database;
+// they are skipped without it.
+const testDSNEnv = "JIRA_TEST_DSN"
+
+func openTestDatabase(t *testing.T) (*Gorm, *gorm.DB) {
+	t.Helper()
+	dsn := os.Getenv(testDSNEnv)
+	if dsn == "" {
+		t.Skipf("%s is not set", testDSNEnv)
+	}
+
+	conn, err := gorm.Open(postgres.Open(dsn), &gorm.Config{Logger: NewLogger()})
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+
+	g := NewGorm(conn)
+	if err := g.Migrate(context.Background()); err != nil {
+		t.Fatalf("Migrate() error = %v", err)
+	}
+
+	return g, conn
+}
+
+func TestGorm_UpdateChangelogCategories(t *testing.T) {
+	g, conn := openTestDatabase(t)
+	ctx := context.Background()
+	t.Cleanup(func() {
+		conn.Exec("delete from changelogs where issue_id = ?", 990001)
+		conn.Exec("delete from issues where id = ?", 990001)
+		conn.Exec("delete from statuses where id in ?", []string{"990001", "990003"})
+	})
+
+	err := g.CreateIssue(ctx, issue.Issue{
+		Stamp:    issue.Stamp{ID: 990001, Key: "CAT-1"},
+		Reporter: issue.Account{ID: "cat-reporter"},
+		Changelog: []issue.Changelog{
+			{ID: 1, Field: issue.FieldStatus, From: "To Do", To: "In Progress", FromID: "990001", ToID: "990003"},
+			{ID: 2, Field: issue.FieldStatus, From: "Doing", To: "Triage", FromID: "990003", ToID: "990404"},
+			{ID: 3, Field: issue.FieldStatus, From: "To Do", To: "Doing", FromID: "990001", ToID: "990003", FromCategory: "done", ToCategory: "done"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("CreateIssue() error = %v", err)
+	}
+
+	for _, s := range []issue.Status{
+		{ID: "990001", Name: "To Do", CategoryKey: "new"},
+		{ID: "990003", Name: "In Progress", CategoryKey: "indeterminate"},
+	} {
+		if err := g.SaveStatus(ctx, s); err != nil {
+			t.Fatalf("SaveStatus() error = %v", err)
+		}
+	}
+
+	if err := g.UpdateChangelogCategories(ctx); err != nil {
+		t.Fatalf("UpdateChangelogCategories() error = %v", err)
+	}
+
+	stored, _, err := g.GetIssueByKey(ctx, "CAT-1")
+	if err != nil {
+		t.Fatalf("GetIssueByKey() error = %v", err)
+	}
+
+	got := map[uint][2]string{}
+	for _, c := range stored.Changelog {
+		got[c.ID] = [2]string{c.FromCategory, c.ToCategory}
+	}
+
+	tests := []struct {
+		name        string
+		changelogID uint
+		want        [2]string
+	}{
+		{name: "fill known statuses", changelogID: 1, want: [2]string{"new", "indeterminate"}},
+		{name: "fill renamed statuses by their id and leave unknown ones empty", changelogID: 2, want: [2]string{"indeterminate", ""}},
+		{name: "keep the categories already resolved", changelogID: 3, want: [2]string{"done", "done"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !reflect.DeepEqual(got[tt.changelogID], tt.want) {
+				t.Errorf("UpdateChangelogCategories() categories = %v, want %v", got[tt.changelogID], tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"jira-integration/pkg/logging"
+	"log/slog"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+const defaultSlowQuery = time.Second
+
+type (
+	// Logger sends gorm's records to the logger of the context: every
+	// statement at debug level, the slow ones as warnings and the failed ones,
+	// except for missing records, as errors.
+	Logger struct {
+		slowQuery time.Duration
+	}
+)
+
+func NewLogger() *Logger {
+	return &Logger{
+		slowQuery: defaultSlowQuery,
+	}
+}
+
+func (l *Logger) LogMode(logger.LogLevel) logger.Interface {
+	return l
+}
+
+func (l *Logger) Info(ctx context.Context, message string, args ...any) {
+	logging.FromContext(ctx).Info(fmt.Sprintf(message, args...))
+}
+
+func (l *Logger) Warn(ctx context.Context, message string, args ...any) {
+	logging.FromContext(ctx).Warn(fmt.Sprintf(message, args...))
+}
+
+func (l *Logger) Error(ctx context.Context, message string, args ...any) {
+	logging.FromContext(ctx).Error(fmt.Sprintf(message, args...))
+}
+
+func (l *Logger) Trace(ctx context.Context, begin time.Time, statement func() (string, int64), err error) {
+	log := logging.FromContext(ctx)
+	duration := time.Since(begin)
+	level := slog.LevelDebug
+	switch {
+	case err != nil && !errors.Is(err, gorm.ErrRecordNotFound):
+		level = slog.LevelError
+	case duration >= l.slowQuery:
+		level = slog.LevelWarn
+	}
+
+	if !log.Enabled(ctx, level) {
+		return
+	}
+
+	sql, rows := statement()
+	attrs := []any{"sql", sql, "rows", rows, "duration", duration}
+	switch level {
+	case slog.LevelError:
+		log.Error("database statement failed", append(attrs, "error", err)...)
+	case slog.LevelWarn:
+		log.Warn("slow database statement", attrs...)
+	default:
+		log.Debug("database statement", attrs...)
+	}
+}
@@ -1,7 +1,9 @@
 package model
 
 import (
+	"jira-integration/pkg/analytics"
 	"jira-integration/pkg/issue"
+	"jira-integration/pkg/job"
 	"time"
 )
 
@@ -17,11 +19,16 @@ type (
 	}
 
 	Changelog struct {
-		ID        uint `gorm:"primarykey"`
-		IssueID   uint
-		From      string
-		To        string
-		CreatedAt time.Time `gorm:"autoCreateTime:false"`
+		ID           uint   `gorm:"primarykey;autoIncrement:false"`
+		Field        string `gorm:"primarykey;default:status"`
+		IssueID      uint
+		From         string
+		To           string
+		FromID       string
+		ToID         string `gorm:"index"`
+		FromCategory string
+		ToCategory   string
+		CreatedAt    time.Time `gorm:"autoCreateTime:false"`
 	}
 
 	Sprint struct {
@@ -32,10 +39,122 @@ type (
 		StartedAt   time.Time
 		EndedAt     time.Time
 		CompletedAt time.Time
+		BoardID     *uint `gorm:"index"`
+		BoardName   string
 	}
 
 	Sprints []Sprint
 
+	Board struct {
+		ID          uint `gorm:"primarykey"`
+		Name        string
+		Type        string
+		ProjectKey  string `gorm:"index"`
+		ProjectName string
+	}
+
+	BoardColumn struct {
+		BoardID  uint `gorm:"primarykey;autoIncrement:false"`
+		Position int  `gorm:"primarykey;autoIncrement:false"`
+		Name     string
+	}
+
+	BoardColumnStatus struct {
+		BoardID  uint   `gorm:"primarykey;autoIncrement:false"`
+		StatusID string `gorm:"primarykey"`
+		Position int
+	}
+
+	Status struct {
+		ID           string `gorm:"primarykey"`
+		Name         string
+		Description  string
+		CategoryID   uint
+		CategoryKey  string
+		CategoryName string
+	}
+
+	Statuses []Status
+
+	Version struct {
+		ID          uint `gorm:"primarykey"`
+		Name        string
+		Description string
+		Project     string `gorm:"index"`
+		Archived    bool
+		Released    bool
+		ReleasedAt  *time.Time
+	}
+
+	IssueMetric struct {
+		IssueID          uint `gorm:"primarykey;autoIncrement:false"`
+		StartedAt        *time.Time
+		DoneAt           *time.Time
+		LeadTimeSeconds  *int64
+		CycleTimeSeconds *int64
+		ActiveSeconds    *int64
+		WaitingSeconds   *int64
+		BlockedSeconds   *int64
+		Reopened         int
+		ComputedAt       time.Time
+	}
+
+	IssueStatusDuration struct {
+		IssueID         uint   `gorm:"primarykey;autoIncrement:false"`
+		Status          string `gorm:"primarykey"`
+		Category        string
+		Visits          int
+		Seconds         int64
+		BusinessSeconds int64
+	}
+
+	IssueStatusDurationRow struct {
+		IssueStatusDuration
+		IssueKey  string
+		Project   string
+		IssueType string
+	}
+
+	WorkItemRow struct {
+		IssueID          uint
+		Key              string
+		Summary          string
+		Project          string
+		IssueType        string
+		Status           string
+		StatusCategory   string
+		Assignee         *string
+		StoryPoints      *uint
+		ParentKey        *string
+		SprintID         *uint
+		SprintName       *string
+		StartedAt        *time.Time
+		DoneAt           *time.Time
+		CycleTimeSeconds *int64
+		ActiveSeconds    *int64
+		WaitingSeconds   *int64
+		BlockedSeconds   *int64
+	}
+
+	// RawPayload is the gzip compressed body of a Jira response, keeping
+	// only the latest one of each request.
+	RawPayload struct {
+		Kind      string `gorm:"primarykey"`
+		Key       string `gorm:"primarykey"`
+		Page      string `gorm:"primarykey"`
+		Body      []byte
+		FetchedAt time.Time
+	}
+
+	Run struct {
+		ID        uint   `gorm:"primarykey"`
+		Job       string `gorm:"index"`
+		StartedAt time.Time
+		EndedAt   *time.Time
+		Count     int
+		Error     *string
+	}
+
 	Account struct {
 		ID           string `gorm:"primarykey"`
 		EmailAddress string
@@ -46,28 +165,31 @@ type (
 	}
 
 	Issue struct {
-		ID          uint   `gorm:"primarykey"`
-		Key         string `gorm:"index,unique"`
-		Summary     string
-		Status      string
-		IssueType   string
-		Project     string
-		ParentID    *uint
-		Parent      *Issue
-		SprintID    *uint
-		Sprint      *Sprint
-		Labels      []Label `gorm:"many2many:issue_labels;"`
-		AssigneeID  *string
-		Assignee    *Account
-		ReporterID  string
-		Reporter    Account
-		StoryPoints *uint
-		Products    []Product `gorm:"many2many:issue_products;"`
-		FixVersion  *string
-		Locality    *string
-		Changelog   []Changelog
-		CreatedAt   time.Time `gorm:"autoCreateTime:false"`
-		UpdatedAt   time.Time `gorm:"autoUpdateTime:false"`
+		ID             uint   `gorm:"primarykey"`
+		Key            string `gorm:"index,unique"`
+		Summary        string
+		Status         string
+		StatusID       string
+		StatusCategory string
+		IssueType      string
+		Project        string
+		ParentID       *uint
+		Parent         *Issue
+		SprintID       *uint
+		Sprint         *Sprint
+		Labels         []Label `gorm:"many2many:issue_labels;"`
+		AssigneeID     *string
+		Assignee       *Account
+		ReporterID     string
+		Reporter       Account
+		StoryPoints    *uint
+		Flagged        bool
+		Products       []Product `gorm:"many2many:issue_products;"`
+		FixVersion     *string
+		Locality       *string
+		Changelog      []Changelog
+		CreatedAt      time.Time `gorm:"autoCreateTime:false"`
+		UpdatedAt      time.Time `gorm:"autoUpdateTime:false"`
 	}
 )
 
@@ -80,6 +202,8 @@ func (s Sprint) ToDomain() issue.Sprint {
 		StartedAt:   s.StartedAt,
 		EndedAt:     s.EndedAt,
 		CompletedAt: s.CompletedAt,
+		BoardID:     pointerToUint(s.BoardID),
+		BoardName:   s.BoardName,
 	}
 }
 
@@ -92,6 +216,196 @@ func (s Sprints) ToDomain() []issue.Sprint {
 	return output
 }
 
+func (r Run) ToDomain() job.Run {
+	output := job.Run{
+		ID:        r.ID,
+		Job:       r.Job,
+		StartedAt: r.StartedAt,
+		Count:     r.Count,
+	}
+
+	if r.EndedAt != nil {
+		output.EndedAt = *r.EndedAt
+	}
+
+	if r.Error != nil {
+		output.Error = *r.Error
+	}
+
+	return output
+}
+
+func (s Status) ToDomain() issue.Status {
+	return issue.Status{
+		ID:           s.ID,
+		Name:         s.Name,
+		Description:  s.Description,
+		CategoryID:   s.CategoryID,
+		CategoryKey:  s.CategoryKey,
+		CategoryName: s.CategoryName,
+	}
+}
+
+func (s Statuses) ToDomain() issue.Statuses {
+	output := make(issue.Statuses, len(s), len(s))
+	for i, status := range s {
+		output[i] = status.ToDomain()
+	}
+
+	return output
+}
+
+func (l Label) ToDomain() issue.Label {
+	return issue.Label(l.Name)
+}
+
+func (p Product) ToDomain() issue.Product {
+	return issue.Product{
+		ID:   p.ID,
+		Name: p.Name,
+	}
+}
+
+func (a Account) ToDomain() issue.Account {
+	return issue.Account{
+		ID:           a.ID,
+		EmailAddress: a.EmailAddress,
+		AvatarURL:    a.AvatarURL,
+		DisplayName:  a.DisplayName,
+		Active:       a.Active,
+		AccountType:  a.AccountType,
+	}
+}
+
+// ToDomain is the inverse of NewIssue. Associations are mapped when they were
+// preloaded; otherwise only the sprint and reporter ids are known.
+func (i Issue) ToDomain() issue.Issue {
+	output := issue.Issue{
+		Stamp: issue.Stamp{
+			ID:        i.ID,
+			Key:       i.Key,
+			CreatedAt: i.CreatedAt,
+			UpdatedAt: i.UpdatedAt,
+		},
+		Summary:        i.Summary,
+		Status:         i.Status,
+		StatusID:       i.StatusID,
+		StatusCategory: i.StatusCategory,
+		IssueType:      i.IssueType,
+		Project:        i.Project,
+		StoryPoints:    i.StoryPoints,
+		Flagged:        i.Flagged,
+		FixVersion:     pointerToString(i.FixVersion),
+		Locality:       pointerToString(i.Locality),
+		Reporter:       i.Reporter.ToDomain(),
+	}
+
+	if output.Reporter.ID == "" {
+		output.Reporter.ID = i.ReporterID
+	}
+
+	if i.Parent != nil {
+		parent := i.Parent.ToDomain()
+		output.Parent = &parent
+	}
+
+	if i.Assignee != nil {
+		assignee := i.Assignee.ToDomain()
+		output.Assignee = &assignee
+	}
+
+	if len(i.Labels) != 0 {
+		output.Labels = make([]issue.Label, len(i.Labels), len(i.Labels))
+		for index, label := range i.Labels {
+			output.Labels[index] = label.ToDomain()
+		}
+	}
+
+	if len(i.Products) != 0 {
+		output.Products = make([]issue.Product, len(i.Products), len(i.Products))
+		for index, product := range i.Products {
+			output.Products[index] = product.ToDomain()
+		}
+	}
+
+	if i.Sprint != nil {
+		sprint := i.Sprint.ToDomain()
+		output.Sprint = &sprint
+	} else if i.SprintID != nil {
+		output.Sprint = &issue.Sprint{ID: *i.SprintID}
+	}
+
+	if len(i.Changelog) != 0 {
+		output.Changelog = make([]issue.Changelog, len(i.Changelog), len(i.Changelog))
+		for index, c := range i.Changelog {
+			output.Changelog[index] = c.ToDomain()
+		}
+	}
+
+	return output
+}
+
+func (r IssueStatusDurationRow) ToDomain() analytics.IssueStatusDuration {
+	return analytics.IssueStatusDuration{
+		IssueID:   r.IssueID,
+		IssueKey:  r.IssueKey,
+		Project:   r.Project,
+		IssueType: r.IssueType,
+		StatusDuration: analytics.StatusDuration{
+			Status:           r.Status,
+			Category:         r.Category,
+			Visits:           r.Visits,
+			Duration:         time.Duration(r.Seconds) * time.Second,
+			BusinessDuration: time.Duration(r.BusinessSeconds) * time.Second,
+		},
+	}
+}
+
+func (r WorkItemRow) ToDomain() analytics.WorkItem {
+	output := analytics.WorkItem{
+		IssueID:        r.IssueID,
+		Key:            r.Key,
+		Summary:        r.Summary,
+		Project:        r.Project,
+		IssueType:      r.IssueType,
+		Status:         r.Status,
+		StatusCategory: r.StatusCategory,
+		Assignee:       pointerToString(r.Assignee),
+		StoryPoints:    r.StoryPoints,
+		ParentKey:      pointerToString(r.ParentKey),
+		SprintID:       pointerToUint(r.SprintID),
+		SprintName:     pointerToString(r.SprintName),
+		ActiveTime:     secondsToDuration(r.ActiveSeconds),
+		WaitingTime:    secondsToDuration(r.WaitingSeconds),
+		BlockedTime:    secondsToDuration(r.BlockedSeconds),
+	}
+
+	if r.StartedAt != nil {
+		output.StartedAt = *r.StartedAt
+	}
+
+	if r.DoneAt != nil {
+		output.DoneAt = *r.DoneAt
+	}
+
+	output.CycleTime = secondsToDuration(r.CycleTimeSeconds)
+	return output
+}
+
+func (c Changelog) ToDomain() issue.Changelog {
+	return issue.Changelog{
+		ID:           c.ID,
+		Field:        c.Field,
+		From:         c.From,
+		To:           c.To,
+		FromID:       c.FromID,
+		ToID:         c.ToID,
+		FromCategory: c.FromCategory,
+		ToCategory:   c.ToCategory,
+		CreatedAt:    c.CreatedAt,
+	}
+}
+
 func NewIssue(i issue.Issue) *Issue {
 	var parent *Issue
 	var parentID *uint
@@ -127,28 +441,31 @@ func NewIssue(i issue.Issue) *Issue {
 	}
 
 	return &Issue{
-		ID:          i.ID,
-		Key:         i.Key,
-		Summary:     i.Summary,
-		Status:      i.Status,
-		IssueType:   i.IssueType,
-		Project:     i.Project,
-		ParentID:    parentID,
-		Parent:      parent,
-		SprintID:    sprintID,
-		Sprint:      NewSprint(i.Sprint),
-		Labels:      labels,
-		AssigneeID:  assigneeID,
-		Assignee:    assignee,
-		ReporterID:  i.Reporter.ID,
-		Reporter:    *NewAccount(&i.Reporter),
-		StoryPoints: i.StoryPoints,
-		Products:    products,
-		FixVersion:  stringToPointer(i.FixVersion),
-		Locality:    stringToPointer(i.Locality),
-		Changelog:   changelog,
-		CreatedAt:   i.CreatedAt,
-		UpdatedAt:   i.UpdatedAt,
+		ID:             i.ID,
+		Key:            i.Key,
+		Summary:        i.Summary,
+		Status:         i.Status,
+		StatusID:       i.StatusID,
+		StatusCategory: i.StatusCategory,
+		IssueType:      i.IssueType,
+		Project:        i.Project,
+		ParentID:       parentID,
+		Parent:         parent,
+		SprintID:       sprintID,
+		Sprint:         NewSprint(i.Sprint),
+		Labels:         labels,
+		AssigneeID:     assigneeID,
+		Assignee:       assignee,
+		ReporterID:     i.Reporter.ID,
+		Reporter:       *NewAccount(&i.Reporter),
+		StoryPoints:    i.StoryPoints,
+		Flagged:        i.Flagged,
+		Products:       products,
+		FixVersion:     stringToPointer(i.FixVersion),
+		Locality:       stringToPointer(i.Locality),
+		Changelog:      changelog,
+		CreatedAt:      i.CreatedAt,
+		UpdatedAt:      i.UpdatedAt,
 	}
 }
 
@@ -167,12 +484,22 @@ func NewProduct(p issue.Product) Product {
 }
 
 func NewChangelog(c issue.Changelog, issueID uint) Changelog {
+	field := c.Field
+	if field == "" {
+		field = issue.FieldStatus
+	}
+
 	return Changelog{
-		ID:        c.ID,
-		IssueID:   issueID,
-		From:      c.From,
-		To:        c.To,
-		CreatedAt: c.CreatedAt,
+		ID:           c.ID,
+		Field:        field,
+		IssueID:      issueID,
+		From:         c.From,
+		To:           c.To,
+		FromID:       c.FromID,
+		ToID:         c.ToID,
+		FromCategory: c.FromCategory,
+		ToCategory:   c.ToCategory,
+		CreatedAt:    c.CreatedAt,
 	}
 }
 
@@ -189,6 +516,111 @@ func NewSprint(sprint *issue.Sprint) *Sprint {
 		StartedAt:   sprint.StartedAt,
 		EndedAt:     sprint.EndedAt,
 		CompletedAt: sprint.CompletedAt,
+		BoardID:     uintToPointer(sprint.BoardID),
+		BoardName:   sprint.BoardName,
+	}
+}
+
+func NewBoard(b issue.Board) *Board {
+	return &Board{
+		ID:          b.ID,
+		Name:        b.Name,
+		Type:        b.Type,
+		ProjectKey:  b.ProjectKey,
+		ProjectName: b.ProjectName,
+	}
+}
+
+func NewBoardColumns(boardID uint, columns []issue.BoardColumn) ([]BoardColumn, []BoardColumnStatus) {
+	outputColumns := make([]BoardColumn, len(columns), len(columns))
+	var outputStatuses []BoardColumnStatus
+	for i, column := range columns {
+		outputColumns[i] = BoardColumn{
+			BoardID:  boardID,
+			Position: column.Position,
+			Name:     column.Name,
+		}
+
+		for _, statusID := range column.StatusIDs {
+			outputStatuses = append(outputStatuses, BoardColumnStatus{
+				BoardID:  boardID,
+				StatusID: statusID,
+				Position: column.Position,
+			})
+		}
+	}
+
+	return outputColumns, outputStatuses
+}
+
+func NewStatus(s issue.Status) *Status {
+	return &Status{
+		ID:           s.ID,
+		Name:         s.Name,
+		Description:  s.Description,
+		CategoryID:   s.CategoryID,
+		CategoryKey:  s.CategoryKey,
+		CategoryName: s.CategoryName,
+	}
+}
+
+func NewVersion(v issue.Version) *Version {
+	return &Version{
+		ID:          v.ID,
+		Name:        v.Name,
+		Description: v.Description,
+		Project:     v.Project,
+		Archived:    v.Archived,
+		Released:    v.Released,
+		ReleasedAt:  timeToPointer(v.ReleasedAt),
+	}
+}
+
+func NewIssueMetric(m analytics.Metrics) (*IssueMetric, []IssueStatusDuration) {
+	metric := &IssueMetric{
+		IssueID:    m.IssueID,
+		StartedAt:  timeToPointer(m.StartedAt),
+		DoneAt:     timeToPointer(m.DoneAt),
+		Reopened:   m.Reopened,
+		ComputedAt: m.ComputedAt,
+	}
+
+	if !m.DoneAt.IsZero() {
+		metric.LeadTimeSeconds = durationToSeconds(m.LeadTime)
+		if !m.StartedAt.IsZero() {
+			metric.CycleTimeSeconds = durationToSeconds(m.CycleTime)
+		}
+	}
+
+	if !m.StartedAt.IsZero() {
+		metric.ActiveSeconds = durationToSeconds(m.ActiveTime)
+		metric.WaitingSeconds = durationToSeconds(m.WaitingTime)
+		metric.BlockedSeconds = durationToSeconds(m.BlockedTime)
+	}
+
+	durations := make([]IssueStatusDuration, len(m.TimeInStatus), len(m.TimeInStatus))
+	for i, d := range m.TimeInStatus {
+		durations[i] = IssueStatusDuration{
+			IssueID:         m.IssueID,
+			Status:          d.Status,
+			Category:        d.Category,
+			Visits:          d.Visits,
+			Seconds:         int64(d.Duration.Seconds()),
+			BusinessSeconds: int64(d.BusinessDuration.Seconds()),
+		}
+	}
+
+	return metric, durations
+}
+
+func NewRun(r job.Run) *Run {
+	return &Run{
+		ID:        r.ID,
+		Job:       r.Job,
+		StartedAt: r.StartedAt,
+		EndedAt:   timeToPointer(r.EndedAt),
+		Count:     r.Count,
+		Error:     stringToPointer(r.Error),
 	}
 }
 
@@ -214,3 +646,48 @@ func stringToPointer(value string) *string {
 
 	return &value
 }
+
+func timeToPointer(value time.Time) *time.Time {
+	if value.IsZero() {
+		return nil
+	}
+
+	return &value
+}
+
+func uintToPointer(value uint) *uint {
+	if value == 0 {
+		return nil
+	}
+
+	return &value
+}
+
+func pointerToUint(value *uint) uint {
+	if value == nil {
+		return 0
+	}
+
+	return *value
+}
+
+func pointerToString(value *string) string {
+	if value == nil {
+		return ""
+	}
+
+	return *value
+}
+
+func durationToSeconds(value time.Duration) *int64 {
+	seconds := int64(value.Seconds())
+	return &seconds
+}
+
+func secondsToDuration(value *int64) time.Duration {
+	if value == nil {
+		return 0
+	}
+
+	return time.Duration(*value) * time.Second
+}
@@ -0,0 +1,66 @@
+package model
+
+import (
+	"jira-integration/pkg/issue"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestIssue_ToDomain(t *testing.T) {
+	createdAt := time.Date(2024, 3, 11, 9, 0, 0, 0, time.UTC)
+	points := uint(5)
+	reporter := issue.Account{ID: "reporter", DisplayName: "Reporter", Active: true}
+
+	tests := []struct {
+		name  string
+		issue issue.Issue
+	}{
+		{
+			name: "map back every field written by NewIssue",
+			issue: issue.Issue{
+				Stamp:          issue.Stamp{ID: 2, Key: "A-2", CreatedAt: createdAt, UpdatedAt: createdAt.Add(time.Hour)},
+				Summary:        "Story",
+				Status:         "In Progress",
+				StatusID:       "3",
+				StatusCategory: issue.StatusCategoryInProgress,
+				IssueType:      "Story",
+				Project:        "A",
+				Parent: &issue.Issue{
+					Stamp:     issue.Stamp{ID: 1, Key: "A-1"},
+					Summary:   "Epic",
+					IssueType: "Epic",
+				},
+				Sprint:      &issue.Sprint{ID: 10, Name: "Sprint 1", State: "active", StartedAt: createdAt, BoardID: 7, BoardName: "Board"},
+				Labels:      []issue.Label{"backend", "urgent"},
+				Assignee:    &issue.Account{ID: "assignee", EmailAddress: "assignee@example.com", DisplayName: "Assignee"},
+				Reporter:    reporter,
+				StoryPoints: &points,
+				Flagged:     true,
+				Products:    []issue.Product{{ID: 1, Name: "Cards"}},
+				FixVersion:  "1.0",
+				Locality:    "BR",
+				Changelog: []issue.Changelog{
+					{ID: 100, Field: issue.FieldStatus, From: "To Do", To: "In Progress", FromID: "1", ToID: "3", CreatedAt: createdAt},
+					{ID: 101, Field: issue.FieldSprint, ToID: "10", CreatedAt: createdAt},
+				},
+			},
+		},
+		{
+			name: "keep optional associations empty",
+			issue: issue.Issue{
+				Stamp:    issue.Stamp{ID: 3, Key: "A-3", CreatedAt: createdAt, UpdatedAt: createdAt},
+				Summary:  "Task",
+				Reporter: reporter,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewIssue(tt.issue).ToDomain(); !reflect.DeepEqual(got, tt.issue) {
+				t.Errorf("ToDomain() = %+v, want %+v", got, tt.issue)
+			}
+		})
+	}
+}
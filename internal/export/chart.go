@@ -0,0 +1,199 @@
+package export
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"math"
+	"strings"
+)
+
+const (
+	chartWidth   = 960
+	chartHeight  = 480
+	chartMargin  = 60
+	chartLegend  = 160
+	chartYTicks  = 5
+	chartXLabels = 10
+)
+
+var (
+	chartColors = []string{"#4c78a8", "#f58518", "#54a24b", "#e45756", "#72b7b2", "#eeca3b", "#b279a2", "#ff9da6", "#9d755d", "#bab0ac"}
+)
+
+type (
+	// Series is one line of a chart. NaN values are gaps.
+	Series struct {
+		Name   string
+		Values []float64
+		Dashed bool
+	}
+
+	// Chart is a line chart, or a stacked area chart when Stacked is set,
+	// sharing the x axis labels across every series.
+	Chart struct {
+		Title   string
+		Labels  []string
+		Series  []Series
+		Stacked bool
+	}
+)
+
+// WriteSVG renders the chart as a standalone SVG document.
+func WriteSVG(w io.Writer, c Chart) error {
+	series := c.Series
+	if c.Stacked {
+		series = stack(series)
+	}
+
+	top := chartTop(series)
+	plotWidth := float64(chartWidth - 2*chartMargin - chartLegend)
+	plotHeight := float64(chartHeight - 2*chartMargin)
+	x := func(i int) float64 {
+		if len(c.Labels) < 2 {
+			return chartMargin
+		}
+
+		return chartMargin + plotWidth*float64(i)/float64(len(c.Labels)-1)
+	}
+	y := func(value float64) float64 {
+		return chartMargin + plotHeight*(1-value/top)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="sans-serif" font-size="12">`+"\n",
+		chartWidth, chartHeight, chartWidth, chartHeight)
+	fmt.Fprintf(&b, `<rect width="100%%" height="100%%" fill="white"/>`+"\n")
+	fmt.Fprintf(&b, `<text x="%d" y="%d" font-size="16">%s</text>`+"\n", chartMargin, chartMargin/2, html.EscapeString(c.Title))
+
+	for tick := 0; tick <= chartYTicks; tick++ {
+		value := top * float64(tick) / chartYTicks
+		fmt.Fprintf(&b, `<line x1="%d" y1="%.1f" x2="%.1f" y2="%.1f" stroke="#e0e0e0"/>`+"\n", chartMargin, y(value), chartMargin+plotWidth, y(value))
+		fmt.Fprintf(&b, `<text x="%d" y="%.1f" text-anchor="end">%s</text>`+"\n", chartMargin-6, y(value)+4, FormatValue(math.Round(value*10)/10))
+	}
+
+	step := max(1, (len(c.Labels)+chartXLabels-1)/chartXLabels)
+	for i, label := range c.Labels {
+		if i%step != 0 && i != len(c.Labels)-1 {
+			continue
+		}
+
+		fmt.Fprintf(&b, `<text x="%.1f" y="%.1f" text-anchor="middle">%s</text>`+"\n", x(i), chartMargin+plotHeight+18, html.EscapeString(label))
+	}
+
+	for i := len(series) - 1; i >= 0; i-- {
+		color := chartColors[i%len(chartColors)]
+		if c.Stacked {
+			writeArea(&b, series, i, x, y, color)
+			continue
+		}
+
+		dash := ""
+		if series[i].Dashed {
+			dash = ` stroke-dasharray="6 4"`
+		}
+
+		for _, segment := range segments(series[i].Values) {
+			var points []string
+			for _, index := range segment {
+				points = append(points, fmt.Sprintf("%.1f,%.1f", x(index), y(series[i].Values[index])))
+			}
+
+			fmt.Fprintf(&b, `<polyline fill="none" stroke="%s" stroke-width="2"%s points="%s"/>`+"\n", color, dash, strings.Join(points, " "))
+		}
+	}
+
+	for i, s := range c.Series {
+		legendY := chartMargin + 20*i
+		fmt.Fprintf(&b, `<rect x="%.1f" y="%d" width="12" height="12" fill="%s"/>`+"\n", chartMargin+plotWidth+20, legendY, chartColors[i%len(chartColors)])
+		fmt.Fprintf(&b, `<text x="%.1f" y="%d">%s</text>`+"\n", chartMargin+plotWidth+38, legendY+10, html.EscapeString(s.Name))
+	}
+
+	fmt.Fprintf(&b, `<line x1="%d" y1="%.1f" x2="%.1f" y2="%.1f" stroke="black"/>`+"\n", chartMargin, chartMargin+plotHeight, chartMargin+plotWidth, chartMargin+plotHeight)
+	fmt.Fprintf(&b, `<line x1="%d" y1="%d" x2="%d" y2="%.1f" stroke="black"/>`+"\n", chartMargin, chartMargin, chartMargin, chartMargin+plotHeight)
+	b.WriteString("</svg>\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// writeArea fills the band between a stacked series and the one below it.
+func writeArea(b *strings.Builder, series []Series, i int, x func(int) float64, y func(float64) float64, color string) {
+	var upper, lower []string
+	for index, value := range series[i].Values {
+		base := 0.0
+		if i > 0 {
+			base = series[i-1].Values[index]
+		}
+
+		upper = append(upper, fmt.Sprintf("%.1f,%.1f", x(index), y(value)))
+		lower = append([]string{fmt.Sprintf("%.1f,%.1f", x(index), y(base))}, lower...)
+	}
+
+	fmt.Fprintf(b, `<polygon fill="%s" fill-opacity="0.85" stroke="none" points="%s"/>`+"\n", color, strings.Join(append(upper, lower...), " "))
+}
+
+// stack turns every series into the running total of the ones before it.
+// Gaps count as zero.
+func stack(series []Series) []Series {
+	output := make([]Series, len(series), len(series))
+	for i, s := range series {
+		values := make([]float64, len(s.Values), len(s.Values))
+		for index, value := range s.Values {
+			if math.IsNaN(value) {
+				value = 0
+			}
+
+			if i > 0 {
+				value += output[i-1].Values[index]
+			}
+
+			values[index] = value
+		}
+
+		output[i] = Series{Name: s.Name, Values: values}
+	}
+
+	return output
+}
+
+func chartTop(series []Series) float64 {
+	var top float64
+	for _, s := range series {
+		for _, value := range s.Values {
+			if !math.IsNaN(value) {
+				top = max(top, value)
+			}
+		}
+	}
+
+	if top == 0 {
+		return 1
+	}
+
+	return top
+}
+
+// segments splits the indexes of the values into runs without gaps.
+func segments(values []float64) [][]int {
+	var output [][]int
+	var current []int
+	for i, value := range values {
+		if math.IsNaN(value) {
+			if len(current) > 0 {
+				output = append(output, current)
+			}
+
+			current = nil
+			continue
+		}
+
+		current = append(current, i)
+	}
+
+	if len(current) > 0 {
+		output = append(output, current)
+	}
+
+	return output
+}
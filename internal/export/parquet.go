@@ -0,0 +1,146 @@
+package export
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+type (
+	// parquetEncoder writes every chunk as a row group. All columns are
+	// optional, so nil values and zero times are written as nulls.
+	parquetEncoder struct {
+		writer  *parquet.Writer
+		columns []Column
+		indexes []int
+	}
+)
+
+func newParquetEncoder(w io.Writer, columns []Column) (*parquetEncoder, error) {
+	group := parquet.Group{}
+	for _, column := range columns {
+		group[column.Name] = parquet.Optional(parquetNode(column.Type))
+	}
+
+	schema := parquet.NewSchema("export", group)
+	indexes := make([]int, len(columns), len(columns))
+	for i, column := range columns {
+		leaf, ok := schema.Lookup(column.Name)
+		if !ok {
+			return nil, fmt.Errorf("while building the parquet schema: column %s not found", column.Name)
+		}
+
+		indexes[i] = leaf.ColumnIndex
+	}
+
+	return &parquetEncoder{
+		writer:  parquet.NewWriter(w, schema),
+		columns: columns,
+		indexes: indexes,
+	}, nil
+}
+
+func (e *parquetEncoder) Encode(rows [][]any) error {
+	output := make([]parquet.Row, len(rows), len(rows))
+	for i, row := range rows {
+		values := make(parquet.Row, len(e.columns), len(e.columns))
+		for j, column := range e.columns {
+			value, err := parquetValue(column, row[j])
+			if err != nil {
+				return err
+			}
+
+			definition := 1
+			if value.IsNull() {
+				definition = 0
+			}
+
+			values[e.indexes[j]] = value.Level(0, definition, e.indexes[j])
+		}
+
+		output[i] = values
+	}
+
+	if _, err := e.writer.WriteRows(output); err != nil {
+		return err
+	}
+
+	return e.writer.Flush()
+}
+
+func (e *parquetEncoder) Close() error {
+	return e.writer.Close()
+}
+
+func parquetNode(columnType ColumnType) parquet.Node {
+	switch columnType {
+	case IntColumn:
+		return parquet.Int(64)
+	case FloatColumn:
+		return parquet.Leaf(parquet.DoubleType)
+	case BoolColumn:
+		return parquet.Leaf(parquet.BooleanType)
+	case TimeColumn:
+		return parquet.Timestamp(parquet.Millisecond)
+	default:
+		return parquet.String()
+	}
+}
+
+func parquetValue(column Column, value any) (parquet.Value, error) {
+	switch v := value.(type) {
+	case nil:
+		return parquet.NullValue(), nil
+	case *uint:
+		if v == nil {
+			return parquet.NullValue(), nil
+		}
+
+		return parquetValue(column, *v)
+	case *float64:
+		if v == nil {
+			return parquet.NullValue(), nil
+		}
+
+		return parquetValue(column, *v)
+	}
+
+	switch column.Type {
+	case IntColumn:
+		switch v := value.(type) {
+		case int:
+			return parquet.Int64Value(int64(v)), nil
+		case int64:
+			return parquet.Int64Value(v), nil
+		case uint:
+			return parquet.Int64Value(int64(v)), nil
+		case uint64:
+			return parquet.Int64Value(int64(v)), nil
+		}
+	case FloatColumn:
+		switch v := value.(type) {
+		case float64:
+			return parquet.DoubleValue(v), nil
+		case int:
+			return parquet.DoubleValue(float64(v)), nil
+		}
+	case BoolColumn:
+		if v, ok := value.(bool); ok {
+			return parquet.BooleanValue(v), nil
+		}
+	case TimeColumn:
+		if v, ok := value.(time.Time); ok {
+			if v.IsZero() {
+				return parquet.NullValue(), nil
+			}
+
+			return parquet.Int64Value(v.UnixMilli()), nil
+		}
+	default:
+		return parquet.ByteArrayValue([]byte(FormatValue(value))), nil
+	}
+
+	return parquet.Value{}, fmt.Errorf("while encoding column %s: unexpected %T value", column.Name, value)
+}
@@ -0,0 +1,112 @@
+package export
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+)
+
+const (
+	FormatNDJSON  = "ndjson"
+	FormatParquet = "parquet"
+)
+
+const (
+	StringColumn ColumnType = iota
+	IntColumn
+	FloatColumn
+	BoolColumn
+	TimeColumn
+)
+
+type (
+	ColumnType int
+
+	// Column names a value of the rows given to an Encoder. The type only
+	// matters to formats with a schema, the others format values as they are.
+	Column struct {
+		Name string
+		Type ColumnType
+	}
+
+	// Encoder writes rows as they come, so large tables can be exported in
+	// chunks instead of being loaded at once. Close must be called to finish
+	// the output, but it doesn't close the underlying writer.
+	Encoder interface {
+		Encode(rows [][]any) error
+		Close() error
+	}
+
+	csvEncoder struct {
+		writer *csv.Writer
+	}
+
+	ndjsonEncoder struct {
+		writer io.Writer
+		header []string
+	}
+)
+
+// NewEncoder returns an encoder of the columns in one of the streaming
+// formats: csv, ndjson or parquet.
+func NewEncoder(w io.Writer, format string, columns []Column) (Encoder, error) {
+	switch format {
+	case FormatCSV:
+		writer := csv.NewWriter(w)
+		if err := writer.Write(ColumnNames(columns)); err != nil {
+			return nil, err
+		}
+
+		return &csvEncoder{writer: writer}, nil
+	case FormatNDJSON:
+		return &ndjsonEncoder{writer: w, header: ColumnNames(columns)}, nil
+	case FormatParquet:
+		return newParquetEncoder(w, columns)
+	default:
+		return nil, fmt.Errorf("%w: %s", UnknownFormatErr, format)
+	}
+}
+
+func ColumnNames(columns []Column) []string {
+	output := make([]string, len(columns), len(columns))
+	for i, column := range columns {
+		output[i] = column.Name
+	}
+
+	return output
+}
+
+func (e *csvEncoder) Encode(rows [][]any) error {
+	for _, row := range rows {
+		if err := e.writer.Write(formatRow(row)); err != nil {
+			return err
+		}
+	}
+
+	e.writer.Flush()
+	return e.writer.Error()
+}
+
+func (e *csvEncoder) Close() error {
+	e.writer.Flush()
+	return e.writer.Error()
+}
+
+func (e *ndjsonEncoder) Encode(rows [][]any) error {
+	for _, row := range rows {
+		object, err := MarshalObject(e.header, row)
+		if err != nil {
+			return err
+		}
+
+		if _, err := e.writer.Write(append(object, '\n')); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (e *ndjsonEncoder) Close() error {
+	return nil
+}
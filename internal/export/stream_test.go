@@ -0,0 +1,100 @@
+package export
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+func TestNewEncoder(t *testing.T) {
+	points := uint(3)
+	columns := []Column{{Name: "key", Type: StringColumn}, {Name: "story_points", Type: IntColumn}, {Name: "done_at", Type: TimeColumn}}
+	chunks := [][][]any{
+		{{"key-1", &points, time.Date(2024, 3, 11, 9, 0, 0, 0, time.UTC)}},
+		{{"key-2", (*uint)(nil), time.Time{}}},
+	}
+
+	tests := []struct {
+		name    string
+		format  string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:   "write csv with the header once",
+			format: FormatCSV,
+			want:   "key,story_points,done_at\nkey-1,3,2024-03-11T09:00:00Z\nkey-2,,\n",
+		},
+		{
+			name:   "write one json object per line",
+			format: FormatNDJSON,
+			want: `{"key":"key-1","story_points":3,"done_at":"2024-03-11T09:00:00Z"}` + "\n" +
+				`{"key":"key-2","story_points":null,"done_at":null}` + "\n",
+		},
+		{
+			name:    "fail on formats that can't be streamed",
+			format:  FormatTable,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := &bytes.Buffer{}
+			encoder, err := NewEncoder(w, tt.format, columns)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewEncoder() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			for _, chunk := range chunks {
+				if err := encoder.Encode(chunk); err != nil {
+					t.Fatalf("Encode() error = %v", err)
+				}
+			}
+			if err := encoder.Close(); err != nil {
+				t.Fatalf("Close() error = %v", err)
+			}
+			if got := w.String(); got != tt.want {
+				t.Errorf("Encode() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("write parquet row groups readable by column name", func(t *testing.T) {
+		w := &bytes.Buffer{}
+		encoder, err := NewEncoder(w, FormatParquet, columns)
+		if err != nil {
+			t.Fatalf("NewEncoder() error = %v", err)
+		}
+		for _, chunk := range chunks {
+			if err := encoder.Encode(chunk); err != nil {
+				t.Fatalf("Encode() error = %v", err)
+			}
+		}
+		if err := encoder.Close(); err != nil {
+			t.Fatalf("Close() error = %v", err)
+		}
+
+		type row struct {
+			Key         string `parquet:"key,optional"`
+			StoryPoints *int64 `parquet:"story_points,optional"`
+			DoneAt      *int64 `parquet:"done_at,optional"`
+		}
+
+		rows, err := parquet.Read[row](bytes.NewReader(w.Bytes()), int64(w.Len()))
+		if err != nil {
+			t.Fatalf("Read() error = %v", err)
+		}
+		if len(rows) != 2 || rows[0].Key != "key-1" || rows[0].StoryPoints == nil || *rows[0].StoryPoints != 3 ||
+			rows[0].DoneAt == nil || *rows[0].DoneAt != chunks[0][0][2].(time.Time).UnixMilli() {
+			t.Fatalf("Read() = %+v", rows)
+		}
+		if rows[1].Key != "key-2" || rows[1].StoryPoints != nil || rows[1].DoneAt != nil {
+			t.Errorf("Read() = %+v, want nulls in the second row", rows[1])
+		}
+	})
+}
@@ -0,0 +1,201 @@
+package export
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"text/tabwriter"
+	"time"
+)
+
+const (
+	FormatTable = "table"
+	FormatCSV   = "csv"
+	FormatJSON  = "json"
+)
+
+var (
+	UnknownFormatErr = errors.New("unknown format")
+)
+
+type (
+	// Table is a tabular result that can be written in any of the formats.
+	// Values are kept typed so JSON output doesn't turn numbers into strings.
+	Table interface {
+		Header() []string
+		Rows() [][]any
+	}
+)
+
+func Write(w io.Writer, format string, t Table) error {
+	switch format {
+	case FormatTable:
+		return writeTable(w, t)
+	case FormatCSV:
+		return writeCSV(w, t)
+	case FormatJSON:
+		return writeJSON(w, t)
+	default:
+		return fmt.Errorf("%w: %s", UnknownFormatErr, format)
+	}
+}
+
+func Hours(d time.Duration) float64 {
+	return math.Round(d.Hours()*100) / 100
+}
+
+func Days(d time.Duration) float64 {
+	return math.Round(d.Hours()/24*100) / 100
+}
+
+func writeTable(w io.Writer, t Table) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	if err := writeTabbed(tw, t.Header()); err != nil {
+		return err
+	}
+
+	for _, row := range t.Rows() {
+		if err := writeTabbed(tw, formatRow(row)); err != nil {
+			return err
+		}
+	}
+
+	return tw.Flush()
+}
+
+func writeTabbed(w io.Writer, values []string) error {
+	for i, value := range values {
+		separator := "\t"
+		if i == len(values)-1 {
+			separator = "\n"
+		}
+
+		if _, err := fmt.Fprint(w, value, separator); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func writeCSV(w io.Writer, t Table) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(t.Header()); err != nil {
+		return err
+	}
+
+	for _, row := range t.Rows() {
+		if err := cw.Write(formatRow(row)); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// writeJSON writes an array of objects keyed by the header, keeping the
+// column order.
+func writeJSON(w io.Writer, t Table) error {
+	header := t.Header()
+	if _, err := io.WriteString(w, "["); err != nil {
+		return err
+	}
+
+	for i, row := range t.Rows() {
+		if i > 0 {
+			if _, err := io.WriteString(w, ","); err != nil {
+				return err
+			}
+		}
+
+		object, err := MarshalObject(header, row)
+		if err != nil {
+			return err
+		}
+
+		if _, err := w.Write(object); err != nil {
+			return err
+		}
+	}
+
+	_, err := io.WriteString(w, "]\n")
+	return err
+}
+
+func MarshalObject(header []string, row []any) ([]byte, error) {
+	output := []byte{'{'}
+	for i, column := range header {
+		if i > 0 {
+			output = append(output, ',')
+		}
+
+		key, err := json.Marshal(column)
+		if err != nil {
+			return nil, err
+		}
+
+		value, err := json.Marshal(jsonValue(row[i]))
+		if err != nil {
+			return nil, err
+		}
+
+		output = append(output, key...)
+		output = append(output, ':')
+		output = append(output, value...)
+	}
+
+	return append(output, '}'), nil
+}
+
+func jsonValue(value any) any {
+	if t, ok := value.(time.Time); ok && t.IsZero() {
+		return nil
+	}
+
+	return value
+}
+
+func formatRow(row []any) []string {
+	output := make([]string, len(row), len(row))
+	for i, value := range row {
+		output[i] = FormatValue(value)
+	}
+
+	return output
+}
+
+func FormatValue(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case time.Time:
+		if v.IsZero() {
+			return ""
+		}
+
+		return v.Format(time.RFC3339)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case *uint:
+		if v == nil {
+			return ""
+		}
+
+		return strconv.FormatUint(uint64(*v), 10)
+	case *float64:
+		if v == nil {
+			return ""
+		}
+
+		return strconv.FormatFloat(*v, 'f', -1, 64)
+	default:
+		return fmt.Sprint(v)
+	}
+}
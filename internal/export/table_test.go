@@ -0,0 +1,61 @@
+package export
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+type (
+	testTable [][]any
+)
+
+func (t testTable) Header() []string {
+	return []string{"key", "hours", "done_at"}
+}
+
+func (t testTable) Rows() [][]any {
+	return t
+}
+
+func TestWrite(t *testing.T) {
+	table := testTable{
+		{"key-1", 1.5, time.Date(2024, 3, 11, 9, 0, 0, 0, time.UTC)},
+		{"key-2", 0.25, time.Time{}},
+	}
+
+	tests := []struct {
+		name    string
+		format  string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:   "write csv with the header first",
+			format: FormatCSV,
+			want:   "key,hours,done_at\nkey-1,1.5,2024-03-11T09:00:00Z\nkey-2,0.25,\n",
+		},
+		{
+			name:   "write json objects keeping column order and types",
+			format: FormatJSON,
+			want:   `[{"key":"key-1","hours":1.5,"done_at":"2024-03-11T09:00:00Z"},{"key":"key-2","hours":0.25,"done_at":null}]` + "\n",
+		},
+		{
+			name:    "fail on unknown formats",
+			format:  "xml",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := &bytes.Buffer{}
+			err := Write(w, tt.format, table)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Write() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := w.String(); got != tt.want {
+				t.Errorf("Write() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
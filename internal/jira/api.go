@@ -14,6 +14,13 @@ const (
 )
 
 var (
+	changelogFields = map[string]string{
+		"status":           issue.FieldStatus,
+		sprintFieldID:      issue.FieldSprint,
+		storyPointsFieldID: issue.FieldStoryPoints,
+		flaggedFieldID:     issue.FieldFlagged,
+	}
+
 	avatarSizes = []AvatarSize{
 		AvatarSize48x48,
 		AvatarSize32x32,
@@ -50,21 +57,41 @@ type (
 	}
 
 	Sprint struct {
-		ID           uint      `json:"id"`
-		Name         string    `json:"name"`
-		State        string    `json:"state"`
-		Goal         string    `json:"goal"`
-		StartDate    time.Time `json:"startDate"`
-		EndDate      time.Time `json:"endDate"`
-		CompleteDate time.Time `json:"completeDate,omitempty"`
+		ID            uint      `json:"id"`
+		Name          string    `json:"name"`
+		State         string    `json:"state"`
+		Goal          string    `json:"goal"`
+		StartDate     time.Time `json:"startDate"`
+		EndDate       time.Time `json:"endDate"`
+		CompleteDate  time.Time `json:"completeDate,omitempty"`
+		BoardID       uint      `json:"boardId,omitempty"`
+		OriginBoardID uint      `json:"originBoardId,omitempty"`
+	}
+
+	BoardLocation struct {
+		ProjectID   uint   `json:"projectId"`
+		ProjectKey  string `json:"projectKey"`
+		ProjectName string `json:"projectName"`
+		DisplayName string `json:"displayName"`
+	}
+
+	Board struct {
+		ID       uint          `json:"id"`
+		Self     string        `json:"self"`
+		Name     string        `json:"name"`
+		Type     string        `json:"type"`
+		Location BoardLocation `json:"location"`
 	}
 
 	Status struct {
 		Field
-		IconURL  string         `json:"iconUrl"`
-		Category StatusCategory `json:"statusCategory"`
+		Description string         `json:"description,omitempty"`
+		IconURL     string         `json:"iconUrl"`
+		Category    StatusCategory `json:"statusCategory"`
 	}
 
+	Statuses []Status
+
 	StatusCategory struct {
 		ID        uint   `json:"id"`
 		Self      string `json:"self,omitempty"`
@@ -110,6 +137,7 @@ type (
 
 	Sprints     []Sprint
 	FixVersions []FixVersion
+	Versions    []FixVersion
 
 	Fields struct {
 		Summary     string      `json:"summary"`
@@ -122,6 +150,7 @@ type (
 		Assignee    *Account    `json:"assignee,omitempty"`
 		Reporter    Account     `json:"reporter"`
 		StoryPoints *float32    `json:"customfield_10025"`
+		Flagged     []Field     `json:"customfield_10021,omitempty"`
 		Product     []Field     `json:"customfield_10693,omitempty"`
 		Project     Project     `json:"project"`
 		FixVersions FixVersions `json:"fixVersions,omitempty"`
@@ -137,6 +166,43 @@ type (
 		Fields Fields `json:"fields"`
 	}
 
+	ColumnStatus struct {
+		ID   string `json:"id"`
+		Self string `json:"self"`
+	}
+
+	Column struct {
+		Name     string         `json:"name"`
+		Statuses []ColumnStatus `json:"statuses"`
+	}
+
+	ColumnConfig struct {
+		Columns []Column `json:"columns"`
+	}
+
+	BoardConfiguration struct {
+		ID           uint         `json:"id"`
+		Name         string       `json:"name"`
+		Type         string       `json:"type"`
+		ColumnConfig ColumnConfig `json:"columnConfig"`
+	}
+
+	AgilePaginated struct {
+		StartAt    int  `json:"startAt"`
+		MaxResults int  `json:"maxResults"`
+		IsLast     bool `json:"isLast"`
+	}
+
+	BoardsResponse struct {
+		AgilePaginated
+		Values []Board `json:"values"`
+	}
+
+	SprintsResponse struct {
+		AgilePaginated
+		Values Sprints `json:"values"`
+	}
+
 	SearchRequest struct {
 		Paginated
 		Fields     []string `json:"fields"`
@@ -197,7 +263,7 @@ type (
 
 func NewChangelogRequest(issueKey, nextPageToken string) ChangelogRequest {
 	return ChangelogRequest{
-		FieldIDs:       []string{"status"},
+		FieldIDs:       []string{"status", sprintFieldID, storyPointsFieldID, flaggedFieldID},
 		IssueIDsOrKeys: []string{issueKey},
 		MaxResults:     defaultMaxResults,
 		Paginated: Paginated{
@@ -225,15 +291,18 @@ func (i Issue) ToDomain() issue.Issue {
 			CreatedAt: time.Time(i.Fields.Created),
 			UpdatedAt: time.Time(i.Fields.Updated),
 		},
-		Summary:     i.Fields.Summary,
-		Status:      i.Fields.Status.Name,
-		IssueType:   i.Fields.IssueType.Name,
-		Project:     i.Fields.Project.Name,
-		Labels:      issue.NewLabels(i.Fields.Labels),
-		Reporter:    i.Fields.Reporter.ToDomain(),
-		StoryPoints: floatPointerToUintPointer(i.Fields.StoryPoints),
-		Locality:    i.Fields.Locality.Value,
-		Changelog:   nil,
+		Summary:        i.Fields.Summary,
+		Status:         i.Fields.Status.Name,
+		StatusID:       i.Fields.Status.ID,
+		StatusCategory: i.Fields.Status.Category.Key,
+		IssueType:      i.Fields.IssueType.Name,
+		Project:        i.Fields.Project.Name,
+		Labels:         issue.NewLabels(i.Fields.Labels),
+		Reporter:       i.Fields.Reporter.ToDomain(),
+		StoryPoints:    floatPointerToUintPointer(i.Fields.StoryPoints),
+		Flagged:        len(i.Fields.Flagged) != 0,
+		Locality:       i.Fields.Locality.Value,
+		Changelog:      nil,
 	}
 
 	if i.Fields.Parent != nil {
@@ -279,15 +348,23 @@ func (c ChangelogResponse) ToDomain() []issue.Changelog {
 }
 
 func (c Changelog) ToDomain() []issue.Changelog {
-	output := make([]issue.Changelog, len(c.Items), len(c.Items))
-	for i, changelogItem := range c.Items {
-		output[i] = issue.Changelog{
+	output := make([]issue.Changelog, 0, len(c.Items))
+	for _, changelogItem := range c.Items {
+		field, tracked := changelogFields[changelogItem.FieldID]
+		if !tracked {
+			continue
+		}
+
+		output = append(output, issue.Changelog{
 			ID:        stringToUint(c.ID),
+			Field:     field,
 			Author:    c.Author.EmailAddress,
 			From:      changelogItem.FromString,
 			To:        changelogItem.ToString,
+			FromID:    changelogItem.From,
+			ToID:      changelogItem.To,
 			CreatedAt: time.UnixMilli(c.Created),
-		}
+		})
 	}
 
 	return output
@@ -322,9 +399,9 @@ func (f FixVersions) GetLast() FixVersion {
 	return last
 }
 
-func (s Sprints) GetLast() Sprint {
+func (s Sprints) GetLast() *Sprint {
 	if len(s) == 0 {
-		return Sprint{}
+		return nil
 	}
 
 	last := s[0]
@@ -334,10 +411,15 @@ func (s Sprints) GetLast() Sprint {
 		}
 	}
 
-	return last
+	return &last
 }
 
 func (s Sprint) ToDomain() *issue.Sprint {
+	boardID := s.OriginBoardID
+	if boardID == 0 {
+		boardID = s.BoardID
+	}
+
 	return &issue.Sprint{
 		ID:          s.ID,
 		Name:        s.Name,
@@ -346,7 +428,95 @@ func (s Sprint) ToDomain() *issue.Sprint {
 		StartedAt:   s.StartDate,
 		EndedAt:     s.EndDate,
 		CompletedAt: s.CompleteDate,
+		BoardID:     boardID,
+	}
+}
+
+func (s Sprints) ToDomain() []issue.Sprint {
+	output := make([]issue.Sprint, len(s), len(s))
+	for i, sprint := range s {
+		output[i] = *sprint.ToDomain()
 	}
+
+	return output
+}
+
+func (b Board) ToDomain() issue.Board {
+	return issue.Board{
+		ID:          b.ID,
+		Name:        b.Name,
+		Type:        b.Type,
+		ProjectKey:  b.Location.ProjectKey,
+		ProjectName: b.Location.ProjectName,
+	}
+}
+
+func (b BoardConfiguration) ToDomain() []issue.BoardColumn {
+	output := make([]issue.BoardColumn, len(b.ColumnConfig.Columns), len(b.ColumnConfig.Columns))
+	for i, column := range b.ColumnConfig.Columns {
+		statusIDs := make([]string, len(column.Statuses), len(column.Statuses))
+		for j, status := range column.Statuses {
+			statusIDs[j] = status.ID
+		}
+
+		output[i] = issue.BoardColumn{
+			Name:      column.Name,
+			Position:  i,
+			StatusIDs: statusIDs,
+		}
+	}
+
+	return output
+}
+
+func (b BoardsResponse) ToDomain() []issue.Board {
+	output := make([]issue.Board, len(b.Values), len(b.Values))
+	for i, board := range b.Values {
+		output[i] = board.ToDomain()
+	}
+
+	return output
+}
+
+func (s Status) ToDomain() issue.Status {
+	return issue.Status{
+		ID:           s.ID,
+		Name:         s.Name,
+		Description:  s.Description,
+		CategoryID:   s.Category.ID,
+		CategoryKey:  s.Category.Key,
+		CategoryName: s.Category.Name,
+	}
+}
+
+func (s Statuses) ToDomain() issue.Statuses {
+	output := make(issue.Statuses, len(s), len(s))
+	for i, status := range s {
+		output[i] = status.ToDomain()
+	}
+
+	return output
+}
+
+func (f FixVersion) ToDomain(project string) issue.Version {
+	return issue.Version{
+		ID:          stringToUint(f.ID),
+		Name:        f.Name,
+		Description: f.Description,
+		Project:     project,
+		Archived:    f.Archived,
+		Released:    f.Released,
+		ReleasedAt:  time.Time(f.Date),
+	}
+}
+
+func (v Versions) ToDomain(project string) []issue.Version {
+	output := make([]issue.Version, len(v), len(v))
+	for i, version := range v {
+		output[i] = version.ToDomain(project)
+	}
+
+	return output
 }
 
 func (a AvatarURLs) GetLargest() string {
@@ -388,7 +558,7 @@ func (d *Date) UnmarshalJSON(bytes []byte) error {
 func (d *Date) MarshalJSON() ([]byte, error) {
 	t := time.Time(*d)
 	formatted := t.Format(time.DateOnly)
-	return []byte(formatted), nil
+	return []byte(strconv.Quote(formatted)), nil
 }
 
 func (dt *DateTime) UnmarshalJSON(bytes []byte) error {
@@ -408,7 +578,7 @@ func (dt *DateTime) UnmarshalJSON(bytes []byte) error {
 func (dt *DateTime) MarshalJSON() ([]byte, error) {
 	t := time.Time(*dt)
 	formatted := t.Format("2006-01-02T15:04:05.999-0700")
-	return []byte(formatted), nil
+	return []byte(strconv.Quote(formatted)), nil
 }
 
 func stringToUint(raw string) uint {
@@ -1,6 +1,7 @@
 package jira
 
 import (
+	"jira-integration/pkg/issue"
 	"reflect"
 	"testing"
 	"time"
@@ -56,3 +57,47 @@ func TestSprints_GetLast(t *testing.T) {
 		})
 	}
 }
+
+func TestBoardConfiguration_ToDomain(t *testing.T) {
+	tests := []struct {
+		name string
+		b    BoardConfiguration
+		want []issue.BoardColumn
+	}{
+		{
+			name: "map the statuses of each column keeping the board order",
+			b: BoardConfiguration{
+				ColumnConfig: ColumnConfig{
+					Columns: []Column{
+						{Name: "To Do", Statuses: []ColumnStatus{{ID: "1"}}},
+						{Name: "In Progress", Statuses: []ColumnStatus{{ID: "3"}, {ID: "10001"}}},
+						{Name: "Done", Statuses: []ColumnStatus{{ID: "10002"}}},
+					},
+				},
+			},
+			want: []issue.BoardColumn{
+				{Name: "To Do", Position: 0, StatusIDs: []string{"1"}},
+				{Name: "In Progress", Position: 1, StatusIDs: []string{"3", "10001"}},
+				{Name: "Done", Position: 2, StatusIDs: []string{"10002"}},
+			},
+		},
+		{
+			name: "keep columns without statuses",
+			b: BoardConfiguration{
+				ColumnConfig: ColumnConfig{
+					Columns: []Column{{Name: "Parking lot"}},
+				},
+			},
+			want: []issue.BoardColumn{
+				{Name: "Parking lot", Position: 0, StatusIDs: []string{}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.b.ToDomain(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ToDomain() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
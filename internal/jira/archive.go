@@ -0,0 +1,106 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"jira-integration/pkg/issue"
+	"strconv"
+)
+
+const (
+	SearchPayload    = "search"
+	IssuePayload     = "issue"
+	ChangelogPayload = "changelog"
+	SprintPayload    = "sprint"
+)
+
+var (
+	PayloadNotFoundErr = errors.New("payload not found in archive")
+)
+
+type (
+	// Archive keeps the raw bodies of Jira responses, identified by kind, the
+	// key of what was requested and the page token, empty for the first page.
+	Archive interface {
+		SavePayload(ctx context.Context, kind, key, page string, body []byte) error
+		GetPayload(ctx context.Context, kind, key, page string) ([]byte, bool, error)
+		EachPayloadKey(ctx context.Context, kind string, batchSize int, fn func(ctx context.Context, keys []string) error) error
+	}
+
+	// ArchiveClient answers issue, changelog and sprint reads from archived
+	// payloads instead of Jira, mapping them the same way Client does.
+	ArchiveClient struct {
+		archive Archive
+	}
+)
+
+func NewArchiveClient(archive Archive) *ArchiveClient {
+	return &ArchiveClient{
+		archive: archive,
+	}
+}
+
+func (c ArchiveClient) GetIssueByID(ctx context.Context, issueID uint) (issue.Issue, error) {
+	var output GetIssueResponse
+	if err := c.decode(ctx, IssuePayload, strconv.FormatUint(uint64(issueID), 10), "", &output); err != nil {
+		return issue.Issue{}, err
+	}
+
+	return output.ToDomain(), nil
+}
+
+func (c ArchiveClient) GetIssueChangelog(ctx context.Context, issueKey, nextPageToken string) ([]issue.Changelog, string, error) {
+	var output ChangelogResponse
+	if err := c.decode(ctx, ChangelogPayload, issueKey, nextPageToken, &output); err != nil {
+		return nil, "", err
+	}
+
+	return output.ToDomain(), output.NextPageToken, nil
+}
+
+func (c ArchiveClient) GetSprint(ctx context.Context, sprintID uint) (*issue.Sprint, error) {
+	var output Sprint
+	if err := c.decode(ctx, SprintPayload, strconv.FormatUint(uint64(sprintID), 10), "", &output); err != nil {
+		return nil, err
+	}
+
+	return output.ToDomain(), nil
+}
+
+// EachIssueID walks through the ids of every archived issue in batches.
+func (c ArchiveClient) EachIssueID(ctx context.Context, batchSize int, fn func(ctx context.Context, issueIDs []uint) error) error {
+	return c.eachID(ctx, IssuePayload, batchSize, fn)
+}
+
+// EachSprintID walks through the ids of every archived sprint in batches.
+func (c ArchiveClient) EachSprintID(ctx context.Context, batchSize int, fn func(ctx context.Context, sprintIDs []uint) error) error {
+	return c.eachID(ctx, SprintPayload, batchSize, fn)
+}
+
+func (c ArchiveClient) eachID(ctx context.Context, kind string, batchSize int, fn func(ctx context.Context, ids []uint) error) error {
+	return c.archive.EachPayloadKey(ctx, kind, batchSize, func(ctx context.Context, keys []string) error {
+		ids := make([]uint, 0, len(keys))
+		for _, key := range keys {
+			if id := stringToUint(key); id != 0 {
+				ids = append(ids, id)
+			}
+		}
+
+		return fn(ctx, ids)
+	})
+}
+
+func (c ArchiveClient) decode(ctx context.Context, kind, key, page string, output any) error {
+	body, exists, err := c.archive.GetPayload(ctx, kind, key, page)
+	if err != nil {
+		return fmt.Errorf("while reading %s %s from archive: %w", kind, key, err)
+	}
+
+	if !exists {
+		return fmt.Errorf("%w: %s %s", PayloadNotFoundErr, kind, key)
+	}
+
+	return json.Unmarshal(body, output)
+}
@@ -0,0 +1,88 @@
+package jira
+
+import (
+	"context"
+	"errors"
+	"jira-integration/internal/jira/mocks"
+	"net/http"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+type (
+	memoryArchive map[[3]string][]byte
+)
+
+func (m memoryArchive) SavePayload(_ context.Context, kind, key, page string, body []byte) error {
+	m[[3]string{kind, key, page}] = body
+	return nil
+}
+
+func (m memoryArchive) GetPayload(_ context.Context, kind, key, page string) ([]byte, bool, error) {
+	body, exists := m[[3]string{kind, key, page}]
+	return body, exists, nil
+}
+
+func (m memoryArchive) EachPayloadKey(ctx context.Context, kind string, _ int, fn func(ctx context.Context, keys []string) error) error {
+	var keys []string
+	for id := range m {
+		if id[0] == kind && id[2] == "" {
+			keys = append(keys, id[1])
+		}
+	}
+
+	sort.Strings(keys)
+	return fn(ctx, keys)
+}
+
+func TestArchiveClient(t *testing.T) {
+	archive := memoryArchive{}
+	client := NewClient("https://jira.local", Credentials{}, &http.Client{
+		Transport: mocks.NewMockedRoundTripper(`{"id":7,"name":"Sprint 7","state":"closed","originBoardId":3}`, http.StatusOK),
+	}).WithArchive(archive)
+
+	fetched, err := client.GetSprint(context.Background(), 7)
+	if err != nil {
+		t.Fatalf("GetSprint() error = %v", err)
+	}
+
+	archived := NewArchiveClient(archive)
+	tests := []struct {
+		name     string
+		sprintID uint
+		wantErr  error
+	}{
+		{
+			name:     "map the archived body the same way as the fetched one",
+			sprintID: 7,
+		},
+		{
+			name:     "fail when the sprint was never archived",
+			sprintID: 8,
+			wantErr:  PayloadNotFoundErr,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := archived.GetSprint(context.Background(), tt.sprintID)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("GetSprint() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && !reflect.DeepEqual(got, fetched) {
+				t.Errorf("GetSprint() = %v, want %v", got, fetched)
+			}
+		})
+	}
+
+	t.Run("walk through the archived sprint ids", func(t *testing.T) {
+		var got []uint
+		err := archived.EachSprintID(context.Background(), 10, func(_ context.Context, sprintIDs []uint) error {
+			got = append(got, sprintIDs...)
+			return nil
+		})
+		if err != nil || !reflect.DeepEqual(got, []uint{7}) {
+			t.Errorf("EachSprintID() = %v, %v, want [7]", got, err)
+		}
+	})
+}
@@ -6,15 +6,29 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"jira-integration/pkg/issue"
+	"jira-integration/pkg/tracing"
 	"net/http"
 	"net/url"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 const (
-	jiraCloudAPIBasePath = "https://bexs.atlassian.net/rest/api/3"
-	jiraAgileAPIBasePath = "https://bexs.atlassian.net/rest/agile/1.0"
-	defaultMaxResults    = 500
+	jiraCloudAPIPath  = "/rest/api/3"
+	jiraAgileAPIPath  = "/rest/agile/1.0"
+	defaultMaxResults = 500
+
+	defaultAgileMaxResults = 50
+
+	sprintFieldID      = "customfield_10020"
+	storyPointsFieldID = "customfield_10025"
+	flaggedFieldID     = "customfield_10021"
 )
 
 var (
@@ -31,13 +45,16 @@ var (
 		"created",
 		"updated",
 		"customfield_10014",
-		"customfield_10020",
-		"customfield_10025",
+		sprintFieldID,
+		storyPointsFieldID,
+		flaggedFieldID,
 		"customfield_10693",
 		"customfield_10696",
 	}
 
 	BadStatusErr = errors.New("bad status")
+
+	tracer = otel.Tracer("jira-integration/internal/jira")
 )
 
 type (
@@ -47,36 +64,63 @@ type (
 	}
 
 	Client struct {
-		credentials Credentials
-		httpClient  *http.Client
+		credentials          Credentials
+		transport            http.RoundTripper
+		observer             Observer
+		httpClient           *http.Client
+		jiraCloudAPIBasePath string
+		jiraAgileAPIBasePath string
+		archive              Archive
 	}
 )
 
-func NewClient(credentials Credentials, client *http.Client) *Client {
+func NewClient(siteURL string, credentials Credentials, client *http.Client) *Client {
 	transport := client.Transport
 	if transport == nil {
 		transport = http.DefaultTransport
 	}
 
-	basicAuthRoundTripper := NewBasicAuthRoundTripper(credentials.Username, credentials.Password, transport)
-
-	return &Client{
-		credentials: credentials,
-		httpClient: &http.Client{
-			Transport: basicAuthRoundTripper,
-		},
+	c := &Client{
+		credentials:          credentials,
+		transport:            transport,
+		jiraCloudAPIBasePath: strings.TrimSuffix(siteURL, "/") + jiraCloudAPIPath,
+		jiraAgileAPIBasePath: strings.TrimSuffix(siteURL, "/") + jiraAgileAPIPath,
 	}
+
+	c.httpClient = c.newHTTPClient()
+	return c
 }
 
-func (c Client) SearchIssuesByJQL(_ context.Context, jql, nextPageToken string) ([]issue.Stamp, string, error) {
-	requestURL := fmt.Sprintf("%s/search/jql", jiraCloudAPIBasePath)
+// WithObserver returns a copy of the client that tells the observer about
+// every request attempt and retry.
+func (c Client) WithObserver(observer Observer) *Client {
+	c.observer = observer
+	c.httpClient = c.newHTTPClient()
+	return &c
+}
+
+// WithArchive returns a copy of the client that saves the raw body of every
+// search page, issue, changelog page and sprint it reads into the archive.
+func (c Client) WithArchive(archive Archive) *Client {
+	c.archive = archive
+	return &c
+}
+
+func (c Client) SearchIssuesByJQL(ctx context.Context, jql, nextPageToken string) (_ []issue.Stamp, _ string, err error) {
+	ctx, span := tracer.Start(ctx, "jira.SearchIssuesByJQL", trace.WithAttributes(
+		attribute.String("jira.jql", jql),
+		attribute.String("jira.page_token", nextPageToken),
+	))
+	defer tracing.End(span, &err)
+
+	requestURL := fmt.Sprintf("%s/search/jql", c.jiraCloudAPIBasePath)
 	params := NewJQLSearchRequest(jql, nextPageToken)
 	rawRequest, err := json.Marshal(&params)
 	if err != nil {
 		return nil, "", err
 	}
 
-	response, err := c.httpClient.Post(requestURL, "application/json", bytes.NewReader(rawRequest))
+	response, err := c.post(ctx, requestURL, rawRequest)
 	if err != nil {
 		return nil, "", err
 	}
@@ -90,15 +134,19 @@ func (c Client) SearchIssuesByJQL(_ context.Context, jql, nextPageToken string)
 	}
 
 	var output SearchResponse
-	if err = json.NewDecoder(response.Body).Decode(&output); err != nil {
+	if err := c.decode(ctx, response.Body, SearchPayload, jql, nextPageToken, &output); err != nil {
 		return nil, "", err
 	}
 
+	span.SetAttributes(attribute.Int("jira.issues", len(output.Issues)))
 	return output.ToDomain(), output.NextPageToken, nil
 }
 
-func (c Client) GetIssueByID(_ context.Context, issueID uint) (issue.Issue, error) {
-	parsedURL, err := url.Parse(fmt.Sprintf("%s/issue/%d", jiraCloudAPIBasePath, issueID))
+func (c Client) GetIssueByID(ctx context.Context, issueID uint) (_ issue.Issue, err error) {
+	ctx, span := tracer.Start(ctx, "jira.GetIssueByID", trace.WithAttributes(attribute.Int64("jira.issue.id", int64(issueID))))
+	defer tracing.End(span, &err)
+
+	parsedURL, err := url.Parse(fmt.Sprintf("%s/issue/%d", c.jiraCloudAPIBasePath, issueID))
 	if err != nil {
 		return issue.Issue{}, err
 	}
@@ -109,7 +157,12 @@ func (c Client) GetIssueByID(_ context.Context, issueID uint) (issue.Issue, erro
 	}
 	parsedURL.RawQuery = query.Encode()
 
-	resp, err := c.httpClient.Get(parsedURL.String())
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, parsedURL.String(), nil)
+	if err != nil {
+		return issue.Issue{}, err
+	}
+
+	resp, err := c.httpClient.Do(request)
 	if err != nil {
 		return issue.Issue{}, err
 	}
@@ -123,22 +176,29 @@ func (c Client) GetIssueByID(_ context.Context, issueID uint) (issue.Issue, erro
 	}
 
 	var output GetIssueResponse
-	if err := json.NewDecoder(resp.Body).Decode(&output); err != nil {
+	if err := c.decode(ctx, resp.Body, IssuePayload, strconv.FormatUint(uint64(issueID), 10), "", &output); err != nil {
 		return issue.Issue{}, err
 	}
 
+	span.SetAttributes(attribute.String("jira.issue.key", output.Key))
 	return output.ToDomain(), nil
 }
 
-func (c Client) GetIssueChangelog(_ context.Context, issueKey, nextPageToken string) ([]issue.Changelog, string, error) {
-	baseURL := fmt.Sprintf("%s/changelog/bulkfetch", jiraCloudAPIBasePath)
+func (c Client) GetIssueChangelog(ctx context.Context, issueKey, nextPageToken string) (_ []issue.Changelog, _ string, err error) {
+	ctx, span := tracer.Start(ctx, "jira.GetIssueChangelog", trace.WithAttributes(
+		attribute.String("jira.issue.key", issueKey),
+		attribute.String("jira.page_token", nextPageToken),
+	))
+	defer tracing.End(span, &err)
+
+	baseURL := fmt.Sprintf("%s/changelog/bulkfetch", c.jiraCloudAPIBasePath)
 	params := NewChangelogRequest(issueKey, nextPageToken)
 	rawRequest, err := json.Marshal(&params)
 	if err != nil {
 		return nil, "", err
 	}
 
-	response, err := c.httpClient.Post(baseURL, "application/json", bytes.NewReader(rawRequest))
+	response, err := c.post(ctx, baseURL, rawRequest)
 	if err != nil {
 		return nil, "", err
 	}
@@ -152,32 +212,159 @@ func (c Client) GetIssueChangelog(_ context.Context, issueKey, nextPageToken str
 	}
 
 	var output ChangelogResponse
-	if err = json.NewDecoder(response.Body).Decode(&output); err != nil {
+	if err := c.decode(ctx, response.Body, ChangelogPayload, issueKey, nextPageToken, &output); err != nil {
 		return nil, "", err
 	}
 
-	return output.ToDomain(), output.NextPageToken, nil
+	changelog := output.ToDomain()
+	span.SetAttributes(attribute.Int("jira.histories", len(changelog)))
+	return changelog, output.NextPageToken, nil
+}
+
+func (c Client) GetSprint(ctx context.Context, sprintID uint) (*issue.Sprint, error) {
+	var output Sprint
+	if err := c.getArchivedJSON(ctx, fmt.Sprintf("%s/sprint/%d", c.jiraAgileAPIBasePath, sprintID), SprintPayload, strconv.FormatUint(uint64(sprintID), 10), &output); err != nil {
+		return nil, err
+	}
+
+	return output.ToDomain(), nil
+}
+
+func (c Client) GetStatuses(ctx context.Context) (issue.Statuses, error) {
+	var output Statuses
+	if err := c.getJSON(ctx, fmt.Sprintf("%s/status", c.jiraCloudAPIBasePath), &output); err != nil {
+		return nil, err
+	}
+
+	return output.ToDomain(), nil
+}
+
+func (c Client) GetProjectVersions(ctx context.Context, projectKeyOrID string) ([]issue.Version, error) {
+	var output Versions
+	if err := c.getJSON(ctx, fmt.Sprintf("%s/project/%s/versions", c.jiraCloudAPIBasePath, url.PathEscape(projectKeyOrID)), &output); err != nil {
+		return nil, err
+	}
+
+	return output.ToDomain(projectKeyOrID), nil
+}
+
+func (c Client) GetBoard(ctx context.Context, boardID uint) (issue.Board, error) {
+	var output Board
+	if err := c.getJSON(ctx, fmt.Sprintf("%s/board/%d", c.jiraAgileAPIBasePath, boardID), &output); err != nil {
+		return issue.Board{}, err
+	}
+
+	return output.ToDomain(), nil
+}
+
+func (c Client) GetBoardColumns(ctx context.Context, boardID uint) ([]issue.BoardColumn, error) {
+	var output BoardConfiguration
+	if err := c.getJSON(ctx, fmt.Sprintf("%s/board/%d/configuration", c.jiraAgileAPIBasePath, boardID), &output); err != nil {
+		return nil, err
+	}
+
+	return output.ToDomain(), nil
+}
+
+func (c Client) GetBoards(ctx context.Context, projectKeyOrID string, startAt int) ([]issue.Board, bool, error) {
+	query := url.Values{}
+	query.Set("startAt", strconv.Itoa(startAt))
+	query.Set("maxResults", strconv.Itoa(defaultAgileMaxResults))
+	if projectKeyOrID != "" {
+		query.Set("projectKeyOrId", projectKeyOrID)
+	}
+
+	var output BoardsResponse
+	if err := c.getJSON(ctx, fmt.Sprintf("%s/board?%s", c.jiraAgileAPIBasePath, query.Encode()), &output); err != nil {
+		return nil, false, err
+	}
+
+	return output.ToDomain(), output.IsLast || len(output.Values) == 0, nil
+}
+
+func (c Client) GetBoardSprints(ctx context.Context, boardID uint, states []string, startAt int) ([]issue.Sprint, bool, error) {
+	query := url.Values{}
+	query.Set("startAt", strconv.Itoa(startAt))
+	query.Set("maxResults", strconv.Itoa(defaultAgileMaxResults))
+	if len(states) != 0 {
+		query.Set("state", strings.Join(states, ","))
+	}
+
+	var output SprintsResponse
+	if err := c.getJSON(ctx, fmt.Sprintf("%s/board/%d/sprint?%s", c.jiraAgileAPIBasePath, boardID, query.Encode()), &output); err != nil {
+		return nil, false, err
+	}
+
+	return output.Values.ToDomain(), output.IsLast || len(output.Values) == 0, nil
+}
+
+func (c Client) newHTTPClient() *http.Client {
+	transport := c.transport
+	if c.observer != nil {
+		transport = NewObservedRoundTripper(transport, c.observer)
+	}
+
+	transport = NewLoggingRoundTripper(transport)
+	retryRoundTripper := NewRetryRoundTripper(transport, defaultRetryAttempts, defaultRetryBackoff, c.observer)
+	basicAuthRoundTripper := NewBasicAuthRoundTripper(c.credentials.Username, c.credentials.Password, retryRoundTripper)
+	return &http.Client{
+		Transport: basicAuthRoundTripper,
+	}
 }
 
-func (c Client) GetSprint(_ context.Context, sprintID uint) (*issue.Sprint, error) {
-	baseURL := fmt.Sprintf("%s/sprint/%d", jiraAgileAPIBasePath, sprintID)
-	response, err := c.httpClient.Get(baseURL)
+func (c Client) post(ctx context.Context, requestURL string, body []byte) (*http.Response, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, requestURL, bytes.NewReader(body))
 	if err != nil {
 		return nil, err
 	}
 
+	request.Header.Set("Content-Type", "application/json")
+	return c.httpClient.Do(request)
+}
+
+func (c Client) getJSON(ctx context.Context, requestURL string, output any) error {
+	return c.getArchivedJSON(ctx, requestURL, "", "", output)
+}
+
+// getArchivedJSON gets and decodes the URL, archiving the body under kind and
+// key when both are given.
+func (c Client) getArchivedJSON(ctx context.Context, requestURL, kind, key string, output any) error {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, requestURL, nil)
+	if err != nil {
+		return err
+	}
+
+	response, err := c.httpClient.Do(request)
+	if err != nil {
+		return err
+	}
+
 	defer func() {
 		_ = response.Body.Close()
 	}()
 
 	if response.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("%w: %s: %d", BadStatusErr, baseURL, response.StatusCode)
+		return fmt.Errorf("%w: %s: %d", BadStatusErr, requestURL, response.StatusCode)
 	}
 
-	var output Sprint
-	if err := json.NewDecoder(response.Body).Decode(&output); err != nil {
-		return nil, err
+	return c.decode(ctx, response.Body, kind, key, "", output)
+}
+
+// decode reads the whole body when there is an archive to save it before
+// decoding, and decodes it as it streams otherwise.
+func (c Client) decode(ctx context.Context, body io.Reader, kind, key, page string, output any) error {
+	if c.archive == nil || kind == "" {
+		return json.NewDecoder(body).Decode(output)
 	}
 
-	return output.ToDomain(), nil
+	raw, err := io.ReadAll(body)
+	if err != nil {
+		return err
+	}
+
+	if err := c.archive.SavePayload(ctx, kind, key, page, raw); err != nil {
+		return fmt.Errorf("while archiving %s %s: %w", kind, key, err)
+	}
+
+	return json.Unmarshal(raw, output)
 }
@@ -2,11 +2,13 @@ package jira
 
 import (
 	"context"
+	"errors"
 	"jira-integration/internal/jira/mocks"
 	"jira-integration/pkg/issue"
 	"net/http"
 	"reflect"
 	"testing"
+	"time"
 )
 
 func TestClient_SearchIssueIDsByJQL(t *testing.T) {
@@ -76,3 +78,218 @@ func TestClient_SearchIssueIDsByJQL(t *testing.T) {
 		})
 	}
 }
+
+func TestClient_GetBoardSprints(t *testing.T) {
+	type fields struct {
+		httpClient *http.Client
+	}
+	type args struct {
+		ctx     context.Context
+		boardID uint
+		states  []string
+		startAt int
+	}
+	tests := []struct {
+		name       string
+		fields     fields
+		args       args
+		want       []issue.Sprint
+		wantIsLast bool
+		wantErr    bool
+	}{
+		{
+			name: "return the sprints of the board including the future ones",
+			fields: fields{
+				httpClient: &http.Client{
+					Transport: mocks.NewMockedRoundTripper(mocks.BoardSprintsResponse, http.StatusOK),
+				},
+			},
+			args: args{
+				ctx:     context.Background(),
+				boardID: 5,
+			},
+			want: []issue.Sprint{
+				{
+					ID:          37,
+					Name:        "Sprint 1",
+					State:       "closed",
+					Goal:        "sprint 1 goal",
+					StartedAt:   time.Date(2024, 3, 11, 15, 22, 0, 0, time.UTC),
+					EndedAt:     time.Date(2024, 3, 25, 1, 22, 0, 0, time.UTC),
+					CompletedAt: time.Date(2024, 3, 25, 11, 4, 0, 0, time.UTC),
+					BoardID:     5,
+				},
+				{
+					ID:      72,
+					Name:    "Sprint 2",
+					State:   "future",
+					BoardID: 5,
+				},
+			},
+			wantIsLast: true,
+		},
+		{
+			name: "return an error on bad status",
+			fields: fields{
+				httpClient: &http.Client{
+					Transport: mocks.NewMockedRoundTripper("", http.StatusUnauthorized),
+				},
+			},
+			args: args{
+				ctx:     context.Background(),
+				boardID: 5,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := Client{
+				httpClient: tt.fields.httpClient,
+			}
+			got, gotIsLast, err := c.GetBoardSprints(tt.args.ctx, tt.args.boardID, tt.args.states, tt.args.startAt)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetBoardSprints() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetBoardSprints() got = %v, want %v", got, tt.want)
+			}
+			if gotIsLast != tt.wantIsLast {
+				t.Errorf("GetBoardSprints() gotIsLast = %v, want %v", gotIsLast, tt.wantIsLast)
+			}
+		})
+	}
+}
+
+func TestClient_Fixture(t *testing.T) {
+	transport, done, err := mocks.NewFixtureRoundTripper("testdata/client.json")
+	if err != nil {
+		t.Fatalf("NewFixtureRoundTripper() error = %v", err)
+	}
+	t.Cleanup(func() {
+		if err := done(); err != nil {
+			t.Errorf("saving the fixture: %v", err)
+		}
+	})
+
+	ctx := context.Background()
+	c := NewClient("https://jira.local", Credentials{Username: "user", Password: "token"}, &http.Client{Transport: transport})
+
+	t.Run("search every page of the jql", func(t *testing.T) {
+		var got []string
+		token := ""
+		for {
+			stamps, next, err := c.SearchIssuesByJQL(ctx, "project = PAY", token)
+			if err != nil {
+				t.Fatalf("SearchIssuesByJQL() error = %v", err)
+			}
+			for _, s := range stamps {
+				got = append(got, s.Key)
+			}
+			if next == "" {
+				break
+			}
+			token = next
+		}
+		if want := []string{"PAY-1", "PAY-2", "PAY-3"}; !reflect.DeepEqual(got, want) {
+			t.Errorf("SearchIssuesByJQL() keys = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("map every field of the issue", func(t *testing.T) {
+		got, err := c.GetIssueByID(ctx, 10001)
+		if err != nil {
+			t.Fatalf("GetIssueByID() error = %v", err)
+		}
+		if got.Key != "PAY-1" || got.Project != "Payments" || got.IssueType != "Story" || got.StatusCategory != issue.StatusCategoryDone {
+			t.Errorf("GetIssueByID() = %+v", got)
+		}
+		if !got.CreatedAt.Equal(time.Date(2024, 3, 4, 12, 0, 0, 0, time.UTC)) {
+			t.Errorf("GetIssueByID() CreatedAt = %v", got.CreatedAt)
+		}
+		if got.Parent == nil || got.Parent.Key != "PAY-0" || got.Sprint == nil || got.Sprint.ID != 38 {
+			t.Errorf("GetIssueByID() Parent = %v, Sprint = %v, want PAY-0 and the latest sprint", got.Parent, got.Sprint)
+		}
+		if got.Assignee == nil || got.Assignee.DisplayName != "Assignee Two" || got.Reporter.EmailAddress != "user-1@example.com" {
+			t.Errorf("GetIssueByID() Assignee = %v, Reporter = %v", got.Assignee, got.Reporter)
+		}
+		if got.StoryPoints == nil || *got.StoryPoints != 5 || !got.Flagged || got.FixVersion != "1.1.0" || got.Locality != "Brazil" {
+			t.Errorf("GetIssueByID() = %+v", got)
+		}
+		if want := []issue.Label{"pix", "backend"}; !reflect.DeepEqual(got.Labels, want) {
+			t.Errorf("GetIssueByID() Labels = %v, want %v", got.Labels, want)
+		}
+		if want := []issue.Product{{ID: 11, Name: "Pix"}}; !reflect.DeepEqual(got.Products, want) {
+			t.Errorf("GetIssueByID() Products = %v, want %v", got.Products, want)
+		}
+	})
+
+	t.Run("keep only the tracked fields of every changelog page", func(t *testing.T) {
+		first, token, err := c.GetIssueChangelog(ctx, "PAY-1", "")
+		if err != nil {
+			t.Fatalf("GetIssueChangelog() error = %v", err)
+		}
+		second, last, err := c.GetIssueChangelog(ctx, "PAY-1", token)
+		if err != nil {
+			t.Fatalf("GetIssueChangelog() error = %v", err)
+		}
+		got := append(first, second...)
+		want := []issue.Changelog{
+			{ID: 50001, Field: issue.FieldStatus, Author: "user-2@example.com", From: "To Do", To: "In Progress", FromID: "10000", ToID: "10001", CreatedAt: time.UnixMilli(1710158400000)},
+			{ID: 50002, Field: issue.FieldStatus, Author: "user-2@example.com", From: "In Progress", To: "Done", FromID: "10001", ToID: "10010", CreatedAt: time.UnixMilli(1710950400000)},
+			{ID: 50002, Field: issue.FieldStoryPoints, Author: "user-2@example.com", From: "3", To: "5", CreatedAt: time.UnixMilli(1710950400000)},
+		}
+		if token != "changelog-2" || last != "" || !reflect.DeepEqual(got, want) {
+			t.Errorf("GetIssueChangelog() = %v, %q, %q, want %v", got, token, last, want)
+		}
+	})
+
+	t.Run("get the sprint and fail on missing ones", func(t *testing.T) {
+		got, err := c.GetSprint(ctx, 38)
+		if err != nil || got.Name != "Sprint 2" || got.BoardID != 5 || got.Goal != "ship refunds" {
+			t.Errorf("GetSprint() = %+v, %v", got, err)
+		}
+		if _, err := c.GetSprint(ctx, 99); !errors.Is(err, BadStatusErr) {
+			t.Errorf("GetSprint() error = %v, want %v", err, BadStatusErr)
+		}
+	})
+
+	t.Run("get the board and its columns", func(t *testing.T) {
+		board, err := c.GetBoard(ctx, 5)
+		if err != nil {
+			t.Fatalf("GetBoard() error = %v", err)
+		}
+		if want := (issue.Board{ID: 5, Name: "PAY board", Type: "scrum", ProjectKey: "PAY", ProjectName: "Payments"}); !reflect.DeepEqual(board, want) {
+			t.Errorf("GetBoard() = %+v, want %+v", board, want)
+		}
+		columns, err := c.GetBoardColumns(ctx, 5)
+		if err != nil {
+			t.Fatalf("GetBoardColumns() error = %v", err)
+		}
+		if len(columns) != 3 || columns[1].Name != "Doing" || !reflect.DeepEqual(columns[1].StatusIDs, []string{"10001", "10002"}) {
+			t.Errorf("GetBoardColumns() = %+v", columns)
+		}
+		boards, isLast, err := c.GetBoards(ctx, "PAY", 0)
+		if err != nil || !isLast || len(boards) != 1 || boards[0].ID != 5 {
+			t.Errorf("GetBoards() = %+v, %v, %v", boards, isLast, err)
+		}
+	})
+
+	t.Run("get the status catalog and the project versions", func(t *testing.T) {
+		statuses, err := c.GetStatuses(ctx)
+		if err != nil {
+			t.Fatalf("GetStatuses() error = %v", err)
+		}
+		if want := map[string]string{"10000": issue.StatusCategoryToDo, "10010": issue.StatusCategoryDone}; !reflect.DeepEqual(statuses.Categories(), want) {
+			t.Errorf("GetStatuses() categories = %v, want %v", statuses.Categories(), want)
+		}
+		versions, err := c.GetProjectVersions(ctx, "PAY")
+		if err != nil {
+			t.Fatalf("GetProjectVersions() error = %v", err)
+		}
+		if len(versions) != 2 || !versions[0].Released || versions[0].Project != "PAY" || !versions[1].ReleasedAt.IsZero() {
+			t.Errorf("GetProjectVersions() = %+v", versions)
+		}
+	})
+}
@@ -0,0 +1,369 @@
+package fake
+
+import (
+	"encoding/json"
+	"fmt"
+	"jira-integration/internal/jira"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultPageSize = 50
+
+	malformedBody = `{"malformed": [`
+)
+
+type (
+	// Seed is what the fake Jira serves. Issues hold the full payloads, and
+	// Changelogs the histories by issue key. Searches map JQL queries to the
+	// keys they return; queries not found return every issue.
+	Seed struct {
+		Issues     []jira.Issue                       `json:"issues"`
+		Changelogs map[string][]jira.Changelog        `json:"changelogs,omitempty"`
+		Searches   map[string][]string                `json:"searches,omitempty"`
+		Sprints    []jira.Sprint                      `json:"sprints,omitempty"`
+		Boards     []jira.Board                       `json:"boards,omitempty"`
+		Columns    map[string]jira.BoardConfiguration `json:"columns,omitempty"`
+		Statuses   jira.Statuses                      `json:"statuses,omitempty"`
+		Versions   map[string]jira.Versions           `json:"versions,omitempty"`
+	}
+
+	// Faults are injected in the responses, so clients can be tested against
+	// a misbehaving Jira.
+	Faults struct {
+		// RateLimited answers the first requests with 429.
+		RateLimited int
+		RetryAfter  time.Duration
+		// Latency delays every response.
+		Latency time.Duration
+		// Malformed answers requests whose path starts with any of the
+		// prefixes with a truncated JSON body.
+		Malformed []string
+	}
+
+	Option func(s *Server)
+
+	// Server is an in-process fake of the subset of the Jira REST v3 and
+	// Agile APIs the client uses, paginating everything by the page size.
+	Server struct {
+		*httptest.Server
+		seed     Seed
+		faults   Faults
+		pageSize int
+		mutex    sync.Mutex
+		requests int
+	}
+
+	window struct {
+		from  int
+		to    int
+		limit int
+	}
+)
+
+func WithPageSize(size int) Option {
+	return func(s *Server) {
+		s.pageSize = size
+	}
+}
+
+func WithFaults(faults Faults) Option {
+	return func(s *Server) {
+		s.faults = faults
+	}
+}
+
+// LoadSeed reads a seed from a JSON file in the same shape as Seed.
+func LoadSeed(path string) (Seed, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return Seed{}, err
+	}
+
+	var seed Seed
+	if err := json.Unmarshal(raw, &seed); err != nil {
+		return Seed{}, fmt.Errorf("while reading seed %s: %w", path, err)
+	}
+
+	return seed, nil
+}
+
+// NewServer starts the fake; it must be closed by the caller.
+func NewServer(seed Seed, options ...Option) *Server {
+	s := &Server{
+		seed:     seed,
+		pageSize: defaultPageSize,
+	}
+
+	for _, option := range options {
+		option(s)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /rest/api/3/search/jql", s.search)
+	mux.HandleFunc("GET /rest/api/3/issue/{id}", s.getIssue)
+	mux.HandleFunc("POST /rest/api/3/changelog/bulkfetch", s.changelog)
+	mux.HandleFunc("GET /rest/api/3/status", s.statuses)
+	mux.HandleFunc("GET /rest/api/3/project/{project}/versions", s.versions)
+	mux.HandleFunc("GET /rest/agile/1.0/sprint/{id}", s.getSprint)
+	mux.HandleFunc("GET /rest/agile/1.0/board", s.boards)
+	mux.HandleFunc("GET /rest/agile/1.0/board/{id}", s.getBoard)
+	mux.HandleFunc("GET /rest/agile/1.0/board/{id}/configuration", s.boardConfiguration)
+	mux.HandleFunc("GET /rest/agile/1.0/board/{id}/sprint", s.boardSprints)
+	s.Server = httptest.NewServer(s.inject(mux))
+	return s
+}
+
+// Requests counts every request received, including the faulty ones.
+func (s *Server) Requests() int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.requests
+}
+
+func (s *Server) inject(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mutex.Lock()
+		s.requests++
+		rateLimited := s.requests <= s.faults.RateLimited
+		s.mutex.Unlock()
+
+		if s.faults.Latency > 0 {
+			select {
+			case <-r.Context().Done():
+				return
+			case <-time.After(s.faults.Latency):
+			}
+		}
+
+		if rateLimited {
+			w.Header().Set("Retry-After", strconv.Itoa(int(s.faults.RetryAfter.Seconds())))
+			writeError(w, http.StatusTooManyRequests, "rate limit exceeded")
+			return
+		}
+
+		for _, prefix := range s.faults.Malformed {
+			if strings.HasPrefix(r.URL.Path, prefix) {
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(malformedBody))
+				return
+			}
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (s *Server) search(w http.ResponseWriter, r *http.Request) {
+	var request jira.SearchRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	issues := s.seed.Issues
+	if keys, ok := s.seed.Searches[request.JQL]; ok {
+		issues = nil
+		for _, i := range s.seed.Issues {
+			if slices.Contains(keys, i.Key) {
+				issues = append(issues, i)
+			}
+		}
+	}
+
+	page, next := paginate(len(issues), request.NextPageToken, s.limit(request.MaxResults))
+	var response jira.SearchResponse
+	response.NextPageToken = next
+	for _, i := range issues[page.from:page.to] {
+		response.Issues = append(response.Issues, jira.SearchResponseIssue{
+			ID:  i.ID,
+			Key: i.Key,
+			Fields: jira.Fields{
+				Created: i.Fields.Created,
+				Updated: i.Fields.Updated,
+			},
+		})
+	}
+
+	writeJSON(w, &response)
+}
+
+func (s *Server) getIssue(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	for _, i := range s.seed.Issues {
+		if i.ID == id || i.Key == id {
+			writeJSON(w, &jira.GetIssueResponse{Issue: i})
+			return
+		}
+	}
+
+	writeError(w, http.StatusNotFound, "Issue does not exist or you do not have permission to see it.")
+}
+
+func (s *Server) changelog(w http.ResponseWriter, r *http.Request) {
+	var request jira.ChangelogRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil || len(request.IssueIDsOrKeys) != 1 {
+		writeError(w, http.StatusBadRequest, "a single issue is expected")
+		return
+	}
+
+	key := request.IssueIDsOrKeys[0]
+	issueID := key
+	for _, i := range s.seed.Issues {
+		if i.ID == key || i.Key == key {
+			key, issueID = i.Key, i.ID
+		}
+	}
+
+	histories := s.seed.Changelogs[key]
+	page, next := paginate(len(histories), request.NextPageToken, s.limit(request.MaxResults))
+	var response jira.ChangelogResponse
+	response.NextPageToken = next
+	if page.to > page.from {
+		response.IssueChangeLogs = []jira.IssueChangelog{{IssueID: issueID, Changelog: histories[page.from:page.to]}}
+	}
+
+	writeJSON(w, &response)
+}
+
+func (s *Server) statuses(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, s.seed.Statuses)
+}
+
+func (s *Server) versions(w http.ResponseWriter, r *http.Request) {
+	versions, ok := s.seed.Versions[r.PathValue("project")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "No project could be found.")
+		return
+	}
+
+	writeJSON(w, versions)
+}
+
+func (s *Server) getSprint(w http.ResponseWriter, r *http.Request) {
+	id := pathID(r)
+	for _, sprint := range s.seed.Sprints {
+		if sprint.ID == id {
+			writeJSON(w, &sprint)
+			return
+		}
+	}
+
+	writeError(w, http.StatusNotFound, "Sprint does not exist or you do not have permission to view it.")
+}
+
+func (s *Server) boards(w http.ResponseWriter, r *http.Request) {
+	project := r.URL.Query().Get("projectKeyOrId")
+	var boards []jira.Board
+	for _, b := range s.seed.Boards {
+		if project == "" || b.Location.ProjectKey == project || strconv.FormatUint(uint64(b.Location.ProjectID), 10) == project {
+			boards = append(boards, b)
+		}
+	}
+
+	page, isLast := s.agilePage(r, len(boards))
+	writeJSON(w, &jira.BoardsResponse{AgilePaginated: page.paginated(isLast), Values: boards[page.from:page.to]})
+}
+
+func (s *Server) getBoard(w http.ResponseWriter, r *http.Request) {
+	id := pathID(r)
+	for _, b := range s.seed.Boards {
+		if b.ID == id {
+			writeJSON(w, &b)
+			return
+		}
+	}
+
+	writeError(w, http.StatusNotFound, "Board does not exist or you do not have permission to see it.")
+}
+
+func (s *Server) boardConfiguration(w http.ResponseWriter, r *http.Request) {
+	configuration, ok := s.seed.Columns[r.PathValue("id")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Board does not exist or you do not have permission to see it.")
+		return
+	}
+
+	writeJSON(w, &configuration)
+}
+
+func (s *Server) boardSprints(w http.ResponseWriter, r *http.Request) {
+	id := pathID(r)
+	states := strings.Split(r.URL.Query().Get("state"), ",")
+	var sprints jira.Sprints
+	for _, sprint := range s.seed.Sprints {
+		boardID := sprint.OriginBoardID
+		if boardID == 0 {
+			boardID = sprint.BoardID
+		}
+
+		if boardID == id && (states[0] == "" || slices.Contains(states, sprint.State)) {
+			sprints = append(sprints, sprint)
+		}
+	}
+
+	sort.SliceStable(sprints, func(a, b int) bool {
+		return sprints[a].ID < sprints[b].ID
+	})
+
+	page, isLast := s.agilePage(r, len(sprints))
+	writeJSON(w, &jira.SprintsResponse{AgilePaginated: page.paginated(isLast), Values: sprints[page.from:page.to]})
+}
+
+func (s *Server) limit(maxResults int) int {
+	if maxResults <= 0 || maxResults > s.pageSize {
+		return s.pageSize
+	}
+
+	return maxResults
+}
+
+// paginate reads the token as the offset of the page, returning the token of
+// the next page or an empty one on the last page.
+func paginate(total int, token string, limit int) (window, string) {
+	from, _ := strconv.Atoi(token)
+	from = min(max(from, 0), total)
+	to := min(from+limit, total)
+	if to >= total {
+		return window{from: from, to: to, limit: limit}, ""
+	}
+
+	return window{from: from, to: to, limit: limit}, strconv.Itoa(to)
+}
+
+func (s *Server) agilePage(r *http.Request, total int) (window, bool) {
+	startAt, _ := strconv.Atoi(r.URL.Query().Get("startAt"))
+	maxResults, _ := strconv.Atoi(r.URL.Query().Get("maxResults"))
+	page, next := paginate(total, strconv.Itoa(startAt), s.limit(maxResults))
+	return page, next == ""
+}
+
+func (w window) paginated(isLast bool) jira.AgilePaginated {
+	return jira.AgilePaginated{StartAt: w.from, MaxResults: w.limit, IsLast: isLast}
+}
+
+func pathID(r *http.Request) uint {
+	id, _ := strconv.ParseUint(r.PathValue("id"), 10, 64)
+	return uint(id)
+}
+
+func writeJSON(w http.ResponseWriter, value any) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(value); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]any{"errorMessages": []string{message}, "errors": map[string]string{}})
+}
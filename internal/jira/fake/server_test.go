@@ -0,0 +1,153 @@
+package fake
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"jira-integration/internal/jira"
+	"jira-integration/pkg/issue"
+	"jira-integration/usecase"
+	"net/http"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+)
+
+type (
+	memoryDatabase struct {
+		issues map[uint]issue.Issue
+	}
+)
+
+func (m *memoryDatabase) GetByID(_ context.Context, issueID uint) (issue.Stamp, bool, error) {
+	i, exists := m.issues[issueID]
+	return i.Stamp, exists, nil
+}
+
+func (m *memoryDatabase) GetStatuses(context.Context) (issue.Statuses, error) {
+	return issue.Statuses{{ID: "1", CategoryKey: issue.StatusCategoryToDo}, {ID: "3", CategoryKey: issue.StatusCategoryDone}}, nil
+}
+
+func (m *memoryDatabase) CreateIssue(_ context.Context, i issue.Issue) error {
+	m.issues[i.ID] = i
+	return nil
+}
+
+func (m *memoryDatabase) UpdateIssue(_ context.Context, i issue.Issue) error {
+	m.issues[i.ID] = i
+	return nil
+}
+
+func newSeed(issues int) Seed {
+	created := jira.DateTime(time.Date(2024, 3, 4, 12, 0, 0, 0, time.UTC))
+	seed := Seed{
+		Changelogs: map[string][]jira.Changelog{},
+		Sprints: []jira.Sprint{
+			{ID: 37, Name: "Sprint 1", State: "closed", OriginBoardID: 5},
+			{ID: 38, Name: "Sprint 2", State: "active", OriginBoardID: 5},
+		},
+	}
+
+	for n := 1; n <= issues; n++ {
+		key := fmt.Sprintf("PAY-%d", n)
+		seed.Issues = append(seed.Issues, jira.Issue{
+			ID:  fmt.Sprint(10000 + n),
+			Key: key,
+			Fields: jira.Fields{
+				Summary: "issue " + key,
+				Status:  jira.Status{Field: jira.Field{ID: "3", Name: "Done"}, Category: jira.StatusCategory{Key: issue.StatusCategoryDone}},
+				Project: jira.Project{Field: jira.Field{Name: "Payments"}, Key: "PAY"},
+				Sprints: jira.Sprints{seed.Sprints[1]},
+				Created: created,
+				Updated: created,
+			},
+		})
+
+		seed.Changelogs[key] = []jira.Changelog{
+			{ID: fmt.Sprint(n*10 + 1), Created: 1710158400000, Items: []jira.ChangelogItem{{FieldID: "status", From: "1", FromString: "To Do", To: "2", ToString: "Doing"}}},
+			{ID: fmt.Sprint(n*10 + 2), Created: 1710950400000, Items: []jira.ChangelogItem{{FieldID: "status", From: "2", FromString: "Doing", To: "3", ToString: "Done"}}},
+		}
+	}
+
+	return seed
+}
+
+func TestServer_Fetch(t *testing.T) {
+	tests := []struct {
+		name         string
+		options      []Option
+		wantRequests int
+		wantErr      bool
+	}{
+		{
+			name:    "fetch every page of the search and of each changelog",
+			options: []Option{WithPageSize(2)},
+			// 3 search pages, then an issue and 1 changelog page for each issue
+			wantRequests: 3 + 5*2,
+		},
+		{
+			name:         "retry rate limited requests",
+			options:      []Option{WithFaults(Faults{RateLimited: 2})},
+			wantRequests: 2 + 1 + 5*2,
+		},
+		{
+			name:    "fail on malformed payloads",
+			options: []Option{WithFaults(Faults{Malformed: []string{"/rest/api/3/changelog"}})},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := NewServer(newSeed(5), tt.options...)
+			defer server.Close()
+
+			db := &memoryDatabase{issues: map[uint]issue.Issue{}}
+			client := jira.NewClient(server.URL, jira.Credentials{Username: "user", Password: "token"}, &http.Client{})
+			fetch := usecase.NewFetchUseCase(client, db)
+			err := usecase.NewStreamUseCase(client, fetch.Execute, db).Execute(context.Background(), "project = PAY")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Execute() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			var keys []string
+			for _, i := range db.issues {
+				keys = append(keys, i.Key)
+				if len(i.Changelog) != 2 || i.Changelog[1].ToCategory != issue.StatusCategoryDone || i.Sprint == nil || i.Sprint.ID != 38 {
+					t.Errorf("Execute() stored %+v", i)
+				}
+			}
+			sort.Strings(keys)
+			if want := []string{"PAY-1", "PAY-2", "PAY-3", "PAY-4", "PAY-5"}; !reflect.DeepEqual(keys, want) {
+				t.Errorf("Execute() stored %v, want %v", keys, want)
+			}
+			if got := server.Requests(); got != tt.wantRequests {
+				t.Errorf("Requests() = %d, want %d", got, tt.wantRequests)
+			}
+		})
+	}
+}
+
+func TestServer_Sprints(t *testing.T) {
+	server := NewServer(newSeed(0), WithPageSize(1), WithFaults(Faults{Latency: 50 * time.Millisecond}))
+	defer server.Close()
+
+	client := jira.NewClient(server.URL, jira.Credentials{}, &http.Client{})
+	sprints, isLast, err := client.GetBoardSprints(context.Background(), 5, []string{"active"}, 0)
+	if err != nil || !isLast || len(sprints) != 1 || sprints[0].ID != 38 {
+		t.Errorf("GetBoardSprints() = %v, %v, %v", sprints, isLast, err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := client.GetSprint(ctx, 38); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("GetSprint() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+
+	if _, err := client.GetSprint(context.Background(), 99); !errors.Is(err, jira.BadStatusErr) {
+		t.Errorf("GetSprint() error = %v, want %v", err, jira.BadStatusErr)
+	}
+}
@@ -0,0 +1,41 @@
+package jira
+
+import (
+	"jira-integration/pkg/logging"
+	"net/http"
+	"time"
+)
+
+type (
+	// LoggingRoundTripper logs every attempt of a request at debug level, and
+	// the ones that got no response as warnings, through the logger of the
+	// request context.
+	LoggingRoundTripper struct {
+		next http.RoundTripper
+	}
+)
+
+func NewLoggingRoundTripper(next http.RoundTripper) http.RoundTripper {
+	return &LoggingRoundTripper{
+		next: next,
+	}
+}
+
+func (l LoggingRoundTripper) RoundTrip(request *http.Request) (*http.Response, error) {
+	start := time.Now()
+	response, err := l.next.RoundTrip(request)
+
+	logger := logging.FromContext(request.Context()).With(
+		"method", request.Method,
+		"endpoint", Endpoint(request.URL.Path),
+		"duration", time.Since(start),
+	)
+
+	if err != nil {
+		logger.Warn("jira request failed", "error", err)
+		return nil, err
+	}
+
+	logger.Debug("jira request", "url", request.URL.Redacted(), "status", response.StatusCode)
+	return response, nil
+}
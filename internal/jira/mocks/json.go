@@ -15,3 +15,29 @@ const SearchResponse = `
     "nextPageToken": "next-page-token"
 }
 `
+
+const BoardSprintsResponse = `
+{
+    "maxResults": 50,
+    "startAt": 0,
+    "isLast": true,
+    "values": [
+        {
+            "id": 37,
+            "state": "closed",
+            "name": "Sprint 1",
+            "startDate": "2024-03-11T15:22:00.000Z",
+            "endDate": "2024-03-25T01:22:00.000Z",
+            "completeDate": "2024-03-25T11:04:00.000Z",
+            "originBoardId": 5,
+            "goal": "sprint 1 goal"
+        },
+        {
+            "id": 72,
+            "state": "future",
+            "name": "Sprint 2",
+            "originBoardId": 5
+        }
+    ]
+}
+`
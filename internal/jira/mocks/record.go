@@ -0,0 +1,346 @@
+package mocks
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+const (
+	// RecordEnv makes fixture round trippers record against the real Jira
+	// instead of replaying the fixture file.
+	RecordEnv = "JIRA_RECORD"
+
+	scrubbedEmailDomain = "example.com"
+)
+
+var (
+	UnmatchedRequestErr = errors.New("no recorded interaction matches the request")
+
+	emailPattern = regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`)
+)
+
+type (
+	// Interaction is a recorded request and the response Jira gave to it.
+	Interaction struct {
+		Request  RecordedRequest  `json:"request"`
+		Response RecordedResponse `json:"response"`
+	}
+
+	// RecordedRequest keeps what identifies a request. Headers are left out,
+	// so credentials never reach the fixture files.
+	RecordedRequest struct {
+		Method string          `json:"method"`
+		Path   string          `json:"path"`
+		Query  string          `json:"query,omitempty"`
+		Body   json.RawMessage `json:"body,omitempty"`
+		Text   string          `json:"text,omitempty"`
+	}
+
+	// RecordedResponse keeps JSON bodies as JSON, for readable fixtures, and
+	// anything else as text.
+	RecordedResponse struct {
+		StatusCode  int             `json:"status_code"`
+		ContentType string          `json:"content_type,omitempty"`
+		Body        json.RawMessage `json:"body,omitempty"`
+		Text        string          `json:"text,omitempty"`
+	}
+
+	// RecordingRoundTripper captures every request and response going through
+	// the next round tripper, with emails scrubbed, until Save writes them to
+	// the fixture file.
+	RecordingRoundTripper struct {
+		path         string
+		next         http.RoundTripper
+		mutex        sync.Mutex
+		interactions []Interaction
+	}
+
+	// ReplayRoundTripper answers requests with the recorded responses,
+	// matching them by method, path, query and body, with emails scrubbed as
+	// they were when recorded. Identical requests get their responses in the
+	// recorded order, repeating the last one.
+	ReplayRoundTripper struct {
+		mutex        sync.Mutex
+		interactions []Interaction
+		replayed     map[int]bool
+	}
+)
+
+func NewRecordingRoundTripper(path string, next http.RoundTripper) *RecordingRoundTripper {
+	return &RecordingRoundTripper{
+		path: path,
+		next: next,
+	}
+}
+
+func (r *RecordingRoundTripper) RoundTrip(request *http.Request) (*http.Response, error) {
+	request, requestBody, err := readRequestBody(request)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := r.next.RoundTrip(request)
+	if err != nil {
+		return nil, err
+	}
+
+	responseBody, err := readResponseBody(response)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	recorded := Interaction{
+		Request: RecordedRequest{
+			Method: request.Method,
+			Path:   request.URL.Path,
+			Query:  scrubQuery(request.URL.RawQuery),
+		},
+		Response: RecordedResponse{
+			StatusCode:  response.StatusCode,
+			ContentType: response.Header.Get("Content-Type"),
+		},
+	}
+
+	recorded.Request.Body, recorded.Request.Text = splitBody(scrubEmails(requestBody))
+	recorded.Response.Body, recorded.Response.Text = splitBody(scrubEmails(responseBody))
+	r.interactions = append(r.interactions, recorded)
+	return response, nil
+}
+
+// Save writes the recorded interactions to the fixture file.
+func (r *RecordingRoundTripper) Save() error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	raw, err := json.MarshalIndent(r.interactions, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(r.path, append(raw, '\n'), 0o644)
+}
+
+// scrubQuery scrubs the emails of each query value, keeping the query as is
+// otherwise.
+func scrubQuery(rawQuery string) string {
+	pairs := strings.Split(rawQuery, "&")
+	for i, pair := range pairs {
+		key, value, found := strings.Cut(pair, "=")
+		unescaped, err := url.QueryUnescape(value)
+		if !found || err != nil {
+			continue
+		}
+
+		if scrubbed := string(scrubEmails([]byte(unescaped))); scrubbed != unescaped {
+			pairs[i] = key + "=" + url.QueryEscape(scrubbed)
+		}
+	}
+
+	return strings.Join(pairs, "&")
+}
+
+// scrubEmails replaces every email by a fake one derived from it, so distinct
+// emails stay distinct and a live request scrubs the same as its recording.
+// Emails already scrubbed are kept.
+func scrubEmails(body []byte) []byte {
+	return emailPattern.ReplaceAllFunc(body, func(email []byte) []byte {
+		if bytes.HasSuffix(email, []byte("@"+scrubbedEmailDomain)) {
+			return email
+		}
+
+		hash := sha256.Sum256(bytes.ToLower(email))
+		return []byte(fmt.Sprintf("user-%s@%s", hex.EncodeToString(hash[:4]), scrubbedEmailDomain))
+	})
+}
+
+func NewReplayRoundTripper(interactions []Interaction) *ReplayRoundTripper {
+	for i, recorded := range interactions {
+		if len(recorded.Request.Body) != 0 {
+			interactions[i].Request.Body, _ = splitBody(recorded.Request.Body)
+		}
+
+		if len(recorded.Response.Body) != 0 {
+			interactions[i].Response.Body, _ = splitBody(recorded.Response.Body)
+		}
+	}
+
+	return &ReplayRoundTripper{
+		interactions: interactions,
+		replayed:     map[int]bool{},
+	}
+}
+
+// LoadReplayRoundTripper replays the interactions of a fixture file.
+func LoadReplayRoundTripper(path string) (*ReplayRoundTripper, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var interactions []Interaction
+	if err := json.Unmarshal(raw, &interactions); err != nil {
+		return nil, fmt.Errorf("while reading fixture %s: %w", path, err)
+	}
+
+	return NewReplayRoundTripper(interactions), nil
+}
+
+func (r *ReplayRoundTripper) RoundTrip(request *http.Request) (*http.Response, error) {
+	if request.Body != nil {
+		defer request.Body.Close()
+	}
+
+	_, body, err := readRequestBody(request)
+	if err != nil {
+		return nil, err
+	}
+
+	requestBody, requestText := splitBody(scrubEmails(body))
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	match := -1
+	for i, recorded := range r.interactions {
+		if !recorded.Request.matches(request, requestBody, requestText) {
+			continue
+		}
+
+		match = i
+		if !r.replayed[i] {
+			break
+		}
+	}
+
+	if match < 0 {
+		return nil, fmt.Errorf("%w: %s %s", UnmatchedRequestErr, request.Method, request.URL.RequestURI())
+	}
+
+	r.replayed[match] = true
+	recorded := r.interactions[match].Response
+	responseBody := recorded.Text
+	if len(recorded.Body) != 0 {
+		responseBody = string(recorded.Body)
+	}
+
+	header := http.Header{}
+	if recorded.ContentType != "" {
+		header.Set("Content-Type", recorded.ContentType)
+	}
+
+	return &http.Response{
+		Status:        http.StatusText(recorded.StatusCode),
+		StatusCode:    recorded.StatusCode,
+		Header:        header,
+		Body:          io.NopCloser(strings.NewReader(responseBody)),
+		ContentLength: int64(len(responseBody)),
+		Request:       request,
+	}, nil
+}
+
+// NewFixtureRoundTripper replays the fixture file, or records it against the
+// real Jira through http.DefaultTransport when RecordEnv is set. The returned
+// function must be called once the test is done, to save the recording.
+func NewFixtureRoundTripper(path string) (http.RoundTripper, func() error, error) {
+	if os.Getenv(RecordEnv) != "" {
+		recorder := NewRecordingRoundTripper(path, http.DefaultTransport)
+		return recorder, recorder.Save, nil
+	}
+
+	replay, err := LoadReplayRoundTripper(path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return replay, func() error { return nil }, nil
+}
+
+func (r RecordedRequest) matches(request *http.Request, body json.RawMessage, text string) bool {
+	return r.Method == request.Method &&
+		r.Path == request.URL.Path &&
+		r.Query == scrubQuery(request.URL.RawQuery) &&
+		bytes.Equal(r.Body, body) &&
+		r.Text == text
+}
+
+// readRequestBody reads the body without modifying the request, as round
+// trippers must: through GetBody when there is one, otherwise by consuming
+// it and returning a clone of the request carrying an unread copy.
+func readRequestBody(request *http.Request) (*http.Request, []byte, error) {
+	if request.Body == nil || request.Body == http.NoBody {
+		return request, nil, nil
+	}
+
+	if request.GetBody != nil {
+		body, err := request.GetBody()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		defer body.Close()
+		raw, err := io.ReadAll(body)
+		return request, raw, err
+	}
+
+	raw, err := io.ReadAll(request.Body)
+	_ = request.Body.Close()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	clone := request.Clone(request.Context())
+	clone.Body = io.NopCloser(bytes.NewReader(raw))
+	return clone, raw, nil
+}
+
+// readResponseBody reads the whole body and puts an unread copy back in
+// place.
+func readResponseBody(response *http.Response) ([]byte, error) {
+	if response.Body == nil || response.Body == http.NoBody {
+		return nil, nil
+	}
+
+	raw, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	_ = response.Body.Close()
+	response.Body = io.NopCloser(bytes.NewReader(raw))
+	return raw, nil
+}
+
+// splitBody normalizes JSON bodies, sorting keys and removing whitespace so
+// they compare regardless of formatting, and returns anything else as text.
+func splitBody(raw []byte) (json.RawMessage, string) {
+	if len(bytes.TrimSpace(raw)) == 0 {
+		return nil, ""
+	}
+
+	var value any
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	if err := decoder.Decode(&value); err != nil || decoder.More() {
+		return nil, string(raw)
+	}
+
+	normalized, err := json.Marshal(value)
+	if err != nil {
+		return nil, string(raw)
+	}
+
+	return normalized, ""
+}
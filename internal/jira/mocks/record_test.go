@@ -0,0 +1,138 @@
+package mocks
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRecordingRoundTripper(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"call":` + string(rune('0'+calls)) + `,"echo":` + string(body) + `,"author":"jane.doe@bank.com","reporter":"jane.doe@bank.com"}`))
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "fixture.json")
+	recorder := NewRecordingRoundTripper(path, http.DefaultTransport)
+	for _, recorded := range []struct{ query, body string }{
+		{query: "page=1", body: `{"jql": "project = PAY", "max": 1}`},
+		{query: "page=1", body: `{"jql": "project = PAY", "max": 1}`},
+		{query: "page=1", body: `{"jql": "reporter = jane.doe@bank.com"}`},
+		{query: "reporter=jane.doe%40bank.com", body: `{}`},
+	} {
+		request, _ := http.NewRequest(http.MethodPost, server.URL+"/search?"+recorded.query, strings.NewReader(recorded.body))
+		request.SetBasicAuth("john@bank.com", "secret-token")
+		body := request.Body
+		response, err := recorder.RoundTrip(request)
+		if err != nil {
+			t.Fatalf("RoundTrip() error = %v", err)
+		}
+		_ = response.Body.Close()
+		if request.Body != body {
+			t.Errorf("RoundTrip() modified the request body")
+		}
+	}
+
+	if err := recorder.Save(); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	raw, _ := os.ReadFile(path)
+	for _, secret := range []string{"bank.com", "secret-token", "Authorization"} {
+		if strings.Contains(string(raw), secret) {
+			t.Errorf("Save() fixture contains %q:\n%s", secret, raw)
+		}
+	}
+
+	scrubbed := string(scrubEmails([]byte("jane.doe@bank.com")))
+	if !strings.HasSuffix(scrubbed, "@example.com") || scrubbed == string(scrubEmails([]byte("john@bank.com"))) {
+		t.Errorf("scrubEmails() = %s, want a distinct example.com email", scrubbed)
+	}
+
+	replay, err := LoadReplayRoundTripper(path)
+	if err != nil {
+		t.Fatalf("LoadReplayRoundTripper() error = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		method  string
+		url     string
+		body    string
+		want    string
+		wantErr error
+	}{
+		{
+			name:   "match json bodies regardless of key order and spacing",
+			method: http.MethodPost,
+			url:    "https://jira.local/search?page=1",
+			body:   `{"max":1,"jql":"project = PAY"}`,
+			want:   `{"author":"` + scrubbed + `","call":1,"echo":{"jql":"project = PAY","max":1},"reporter":"` + scrubbed + `"}`,
+		},
+		{
+			name:   "replay identical requests in the recorded order",
+			method: http.MethodPost,
+			url:    "https://jira.local/search?page=1",
+			body:   `{"jql":"project = PAY","max":1}`,
+			want:   `{"author":"` + scrubbed + `","call":2,"echo":{"jql":"project = PAY","max":1},"reporter":"` + scrubbed + `"}`,
+		},
+		{
+			name:   "repeat the last response once all were replayed",
+			method: http.MethodPost,
+			url:    "https://jira.local/search?page=1",
+			body:   `{"jql":"project = PAY","max":1}`,
+			want:   `{"author":"` + scrubbed + `","call":2,"echo":{"jql":"project = PAY","max":1},"reporter":"` + scrubbed + `"}`,
+		},
+		{
+			name:   "match bodies with emails as scrubbed when recorded",
+			method: http.MethodPost,
+			url:    "https://jira.local/search?page=1",
+			body:   `{"jql":"reporter = jane.doe@bank.com"}`,
+			want:   `{"author":"` + scrubbed + `","call":3,"echo":{"jql":"reporter = ` + scrubbed + `"},"reporter":"` + scrubbed + `"}`,
+		},
+		{
+			name:   "match queries with emails as scrubbed when recorded",
+			method: http.MethodPost,
+			url:    "https://jira.local/search?reporter=jane.doe%40bank.com",
+			body:   `{}`,
+			want:   `{"author":"` + scrubbed + `","call":4,"echo":{},"reporter":"` + scrubbed + `"}`,
+		},
+		{
+			name:    "fail on requests that weren't recorded",
+			method:  http.MethodPost,
+			url:     "https://jira.local/search?page=2",
+			body:    `{"jql":"project = PAY","max":1}`,
+			wantErr: UnmatchedRequestErr,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request, _ := http.NewRequest(tt.method, tt.url, strings.NewReader(tt.body))
+			request.GetBody = nil // replay from the body itself, unlike the recording
+			body := request.Body
+			response, err := replay.RoundTrip(request)
+			if request.Body != body {
+				t.Errorf("RoundTrip() modified the request body")
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("RoundTrip() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			got, _ := io.ReadAll(response.Body)
+			if string(got) != tt.want || response.StatusCode != http.StatusOK {
+				t.Errorf("RoundTrip() = %d %s, want %s", response.StatusCode, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package jira
+
+import (
+	"net/http"
+	"strings"
+	"time"
+)
+
+const idPlaceholder = "{id}"
+
+var (
+	// idParents are the path segments followed by an id or key.
+	idParents = map[string]bool{
+		"issue":   true,
+		"project": true,
+		"sprint":  true,
+		"board":   true,
+	}
+)
+
+type (
+	// Observer is told about every attempt of a request sent to Jira, with
+	// status code 0 when no response came back, and about every wait before
+	// retrying one. Endpoints are paths with the ids replaced by "{id}", so
+	// they are fit to be metric labels.
+	Observer interface {
+		ObserveRequest(endpoint, method string, statusCode int, duration time.Duration)
+		ObserveRetry(endpoint string, statusCode int, wait time.Duration)
+	}
+
+	ObservedRoundTripper struct {
+		next     http.RoundTripper
+		observer Observer
+	}
+)
+
+func NewObservedRoundTripper(next http.RoundTripper, observer Observer) http.RoundTripper {
+	return &ObservedRoundTripper{
+		next:     next,
+		observer: observer,
+	}
+}
+
+func (o ObservedRoundTripper) RoundTrip(request *http.Request) (*http.Response, error) {
+	start := time.Now()
+	response, err := o.next.RoundTrip(request)
+
+	statusCode := 0
+	if err == nil {
+		statusCode = response.StatusCode
+	}
+
+	o.observer.ObserveRequest(Endpoint(request.URL.Path), request.Method, statusCode, time.Since(start))
+	return response, err
+}
+
+// Endpoint replaces the ids and keys of a request path by a placeholder.
+func Endpoint(path string) string {
+	segments := strings.Split(path, "/")
+	for i := 1; i < len(segments); i++ {
+		if idParents[segments[i-1]] && segments[i] != "" {
+			segments[i] = idPlaceholder
+		}
+	}
+
+	return strings.Join(segments, "/")
+}
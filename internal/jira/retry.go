@@ -0,0 +1,131 @@
+package jira
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"jira-integration/pkg/logging"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	defaultRetryAttempts = 4
+	defaultRetryBackoff  = time.Second
+	maxRetryWait         = time.Minute
+)
+
+type (
+	// RetryRoundTripper retries requests Jira rate limited or couldn't serve
+	// for a while, waiting for the Retry-After header when there is one and
+	// backing off exponentially otherwise. The observer, when given, is told
+	// about every wait.
+	RetryRoundTripper struct {
+		next     http.RoundTripper
+		attempts int
+		backoff  time.Duration
+		observer Observer
+	}
+)
+
+func NewRetryRoundTripper(next http.RoundTripper, attempts int, backoff time.Duration, observer Observer) http.RoundTripper {
+	return &RetryRoundTripper{
+		next:     next,
+		attempts: attempts,
+		backoff:  backoff,
+		observer: observer,
+	}
+}
+
+// RoundTrip sends a clone of the request on every retry, since the request
+// itself must not be modified, with its body rewound through GetBody. Requests
+// whose body can't be rewound aren't retried.
+func (r RetryRoundTripper) RoundTrip(request *http.Request) (*http.Response, error) {
+	replayable := request.Body == nil || request.Body == http.NoBody || request.GetBody != nil
+	for attempt := 1; ; attempt++ {
+		attemptRequest, err := r.attemptRequest(request, attempt)
+		if err != nil {
+			return nil, err
+		}
+
+		response, err := r.next.RoundTrip(attemptRequest)
+		if err != nil || !isRetryable(response.StatusCode) || attempt >= r.attempts || !replayable {
+			return response, err
+		}
+
+		wait := r.wait(response, attempt)
+		logging.FromContext(request.Context()).Warn("retrying jira request",
+			"endpoint", Endpoint(request.URL.Path),
+			"status", response.StatusCode,
+			"attempt", attempt,
+			"wait", wait,
+		)
+
+		if r.observer != nil {
+			r.observer.ObserveRetry(Endpoint(request.URL.Path), response.StatusCode, wait)
+		}
+
+		_, _ = io.Copy(io.Discard, response.Body)
+		_ = response.Body.Close()
+		if err := sleep(request.Context(), wait); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (r RetryRoundTripper) attemptRequest(request *http.Request, attempt int) (*http.Request, error) {
+	if attempt == 1 {
+		return request, nil
+	}
+
+	clone := request.Clone(request.Context())
+	if request.GetBody != nil {
+		body, err := request.GetBody()
+		if err != nil {
+			return nil, fmt.Errorf("while rewinding the request body: %w", err)
+		}
+
+		clone.Body = body
+	}
+
+	return clone, nil
+}
+
+// wait honors Retry-After, in seconds or as a date, up to a minute.
+func (r RetryRoundTripper) wait(response *http.Response, attempt int) time.Duration {
+	wait := r.backoff << (attempt - 1)
+	if retryAfter := response.Header.Get("Retry-After"); retryAfter != "" {
+		if seconds, err := strconv.Atoi(retryAfter); err == nil {
+			wait = time.Duration(seconds) * time.Second
+		} else if date, err := http.ParseTime(retryAfter); err == nil {
+			wait = time.Until(date)
+		}
+	}
+
+	return min(max(wait, 0), maxRetryWait)
+}
+
+func isRetryable(statusCode int) bool {
+	switch statusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,165 @@
+package jira
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+type (
+	scriptedRoundTripper struct {
+		statuses []int
+		bodies   []string
+		requests []*http.Request
+	}
+
+	retryObserver struct {
+		retries []int
+	}
+)
+
+func (s *scriptedRoundTripper) RoundTrip(request *http.Request) (*http.Response, error) {
+	body := ""
+	if request.Body != nil {
+		raw, _ := io.ReadAll(request.Body)
+		body = string(raw)
+	}
+
+	s.requests = append(s.requests, request)
+	s.bodies = append(s.bodies, body)
+	status := s.statuses[min(len(s.requests), len(s.statuses))-1]
+	return &http.Response{StatusCode: status, Header: http.Header{}, Body: http.NoBody, Request: request}, nil
+}
+
+func (r *retryObserver) ObserveRequest(string, string, int, time.Duration) {}
+
+func (r *retryObserver) ObserveRetry(_ string, statusCode int, _ time.Duration) {
+	r.retries = append(r.retries, statusCode)
+}
+
+func TestRetryRoundTripper_RoundTrip(t *testing.T) {
+	tests := []struct {
+		name        string
+		statuses    []int
+		newRequest  func() *http.Request
+		wantStatus  int
+		wantBodies  []string
+		wantRetries []int
+	}{
+		{
+			name:     "retry rate limited and unavailable responses",
+			statuses: []int{http.StatusTooManyRequests, http.StatusServiceUnavailable, http.StatusOK},
+			newRequest: func() *http.Request {
+				request, _ := http.NewRequest(http.MethodGet, "https://jira.local/rest/api/3/issue/1", nil)
+				return request
+			},
+			wantStatus:  http.StatusOK,
+			wantBodies:  []string{"", "", ""},
+			wantRetries: []int{http.StatusTooManyRequests, http.StatusServiceUnavailable},
+		},
+		{
+			name:     "give up after the last attempt",
+			statuses: []int{http.StatusServiceUnavailable},
+			newRequest: func() *http.Request {
+				request, _ := http.NewRequest(http.MethodGet, "https://jira.local/rest/api/3/issue/1", nil)
+				return request
+			},
+			wantStatus:  http.StatusServiceUnavailable,
+			wantBodies:  []string{"", "", ""},
+			wantRetries: []int{http.StatusServiceUnavailable, http.StatusServiceUnavailable},
+		},
+		{
+			name:     "don't retry other errors",
+			statuses: []int{http.StatusBadRequest},
+			newRequest: func() *http.Request {
+				request, _ := http.NewRequest(http.MethodGet, "https://jira.local/rest/api/3/issue/1", nil)
+				return request
+			},
+			wantStatus: http.StatusBadRequest,
+			wantBodies: []string{""},
+		},
+		{
+			name:     "replay the body on every attempt",
+			statuses: []int{http.StatusTooManyRequests, http.StatusOK},
+			newRequest: func() *http.Request {
+				request, _ := http.NewRequest(http.MethodPost, "https://jira.local/rest/api/3/search/jql", strings.NewReader(`{"jql":"project = PAY"}`))
+				return request
+			},
+			wantStatus:  http.StatusOK,
+			wantBodies:  []string{`{"jql":"project = PAY"}`, `{"jql":"project = PAY"}`},
+			wantRetries: []int{http.StatusTooManyRequests},
+		},
+		{
+			name:     "don't retry bodies that can't be replayed",
+			statuses: []int{http.StatusTooManyRequests, http.StatusOK},
+			newRequest: func() *http.Request {
+				request, _ := http.NewRequest(http.MethodPost, "https://jira.local/rest/api/3/search/jql", nil)
+				request.Body = io.NopCloser(strings.NewReader(`{"jql":"project = PAY"}`))
+				return request
+			},
+			wantStatus: http.StatusTooManyRequests,
+			wantBodies: []string{`{"jql":"project = PAY"}`},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next := &scriptedRoundTripper{statuses: tt.statuses}
+			observer := &retryObserver{}
+			request := tt.newRequest()
+			body := request.Body
+
+			response, err := NewRetryRoundTripper(next, 3, time.Millisecond, observer).RoundTrip(request)
+			if err != nil {
+				t.Fatalf("RoundTrip() error = %v", err)
+			}
+			if response.StatusCode != tt.wantStatus {
+				t.Errorf("RoundTrip() status = %d, want %d", response.StatusCode, tt.wantStatus)
+			}
+			if strings.Join(next.bodies, "|") != strings.Join(tt.wantBodies, "|") {
+				t.Errorf("RoundTrip() sent bodies %q, want %q", next.bodies, tt.wantBodies)
+			}
+			if len(observer.retries) != len(tt.wantRetries) {
+				t.Errorf("RoundTrip() retried %v, want %v", observer.retries, tt.wantRetries)
+			}
+			if request.Body != body {
+				t.Errorf("RoundTrip() modified the request body")
+			}
+			for _, sent := range next.requests[1:] {
+				if sent == request {
+					t.Errorf("RoundTrip() sent the original request on a retry")
+				}
+			}
+		})
+	}
+}
+
+func TestRetryRoundTripper_wait(t *testing.T) {
+	tests := []struct {
+		name       string
+		retryAfter string
+		attempt    int
+		want       time.Duration
+	}{
+		{name: "back off exponentially without retry-after", attempt: 3, want: 4 * time.Second},
+		{name: "wait the retry-after seconds", retryAfter: "7", attempt: 1, want: 7 * time.Second},
+		{name: "cap the retry-after seconds", retryAfter: "3600", attempt: 1, want: maxRetryWait},
+		{name: "cap the retry-after date", retryAfter: time.Now().Add(time.Hour).UTC().Format(http.TimeFormat), attempt: 1, want: maxRetryWait},
+		{name: "don't wait for past dates", retryAfter: "Mon, 04 Mar 2024 12:00:00 GMT", attempt: 1, want: 0},
+		{name: "back off on invalid retry-after", retryAfter: "soon", attempt: 2, want: 2 * time.Second},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := RetryRoundTripper{backoff: time.Second}
+			response := &http.Response{Header: http.Header{}}
+			if tt.retryAfter != "" {
+				response.Header.Set("Retry-After", tt.retryAfter)
+			}
+
+			if got := r.wait(response, tt.attempt); got != tt.want {
+				t.Errorf("wait() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
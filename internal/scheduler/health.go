@@ -0,0 +1,93 @@
+package scheduler
+
+import (
+	"context"
+	"encoding/json"
+	"jira-integration/pkg/job"
+	"net/http"
+	"time"
+)
+
+const (
+	HealthStatusOK       = "ok"
+	HealthStatusDegraded = "degraded"
+	HealthStatusDown     = "down"
+)
+
+type (
+	Pinger interface {
+		Ping(ctx context.Context) error
+	}
+
+	JobHealth struct {
+		Name     string    `json:"name"`
+		Schedule string    `json:"schedule"`
+		Running  bool      `json:"running"`
+		NextRun  time.Time `json:"next_run"`
+		LastRun  *job.Run  `json:"last_run,omitempty"`
+	}
+
+	Health struct {
+		Status string      `json:"status"`
+		Error  string      `json:"error,omitempty"`
+		Jobs   []JobHealth `json:"jobs"`
+	}
+)
+
+func (s *Scheduler) Health(ctx context.Context, pinger Pinger) Health {
+	output := Health{
+		Status: HealthStatusOK,
+		Jobs:   make([]JobHealth, len(s.entries), len(s.entries)),
+	}
+
+	if err := pinger.Ping(ctx); err != nil {
+		output.Status = HealthStatusDown
+		output.Error = err.Error()
+	}
+
+	for i, e := range s.entries {
+		output.Jobs[i] = JobHealth{
+			Name:     e.Name,
+			Schedule: e.Schedule,
+			Running:  e.running.Load(),
+			NextRun:  s.cron.Entry(e.id).Next,
+		}
+
+		if output.Status == HealthStatusDown {
+			continue
+		}
+
+		lastRun, exists, err := s.db.GetLastRun(ctx, e.Name)
+		if err != nil {
+			output.Status = HealthStatusDown
+			output.Error = err.Error()
+			continue
+		}
+
+		if !exists {
+			continue
+		}
+
+		output.Jobs[i].LastRun = &lastRun
+		if lastRun.Failed() {
+			output.Status = HealthStatusDegraded
+		}
+	}
+
+	return output
+}
+
+func (s *Scheduler) HealthHandler(pinger Pinger) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		health := s.Health(r.Context(), pinger)
+
+		statusCode := http.StatusOK
+		if health.Status == HealthStatusDown {
+			statusCode = http.StatusServiceUnavailable
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(statusCode)
+		_ = json.NewEncoder(w).Encode(health)
+	})
+}
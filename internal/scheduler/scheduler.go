@@ -0,0 +1,156 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"jira-integration/pkg/job"
+	"jira-integration/pkg/logging"
+	"jira-integration/pkg/tracing"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// finishTimeout bounds recording the end of a run, which must happen even
+	// after the daemon context is cancelled.
+	finishTimeout = 10 * time.Second
+)
+
+var (
+	DuplicatedJobErr = errors.New("duplicated job")
+
+	tracer = otel.Tracer("jira-integration/internal/scheduler")
+)
+
+type (
+	Task func(ctx context.Context) (int, error)
+
+	Job struct {
+		Name     string
+		Schedule string
+		Task     Task
+	}
+
+	RunDatabase interface {
+		CreateRun(ctx context.Context, run job.Run) (job.Run, error)
+		UpdateRun(ctx context.Context, run job.Run) error
+		GetLastRun(ctx context.Context, jobName string) (job.Run, bool, error)
+	}
+
+	Scheduler struct {
+		cron    *cron.Cron
+		db      RunDatabase
+		entries []*entry
+		now     func() time.Time
+	}
+
+	entry struct {
+		Job
+		id      cron.EntryID
+		lock    sync.Mutex
+		running atomic.Bool
+	}
+)
+
+func NewScheduler(db RunDatabase) *Scheduler {
+	return &Scheduler{
+		cron: cron.New(),
+		db:   db,
+		now:  time.Now,
+	}
+}
+
+func (s *Scheduler) Add(ctx context.Context, j Job) error {
+	for _, e := range s.entries {
+		if e.Name == j.Name {
+			return fmt.Errorf("%w: %s", DuplicatedJobErr, j.Name)
+		}
+	}
+
+	e := &entry{Job: j}
+	id, err := s.cron.AddFunc(j.Schedule, func() {
+		_ = s.run(ctx, e)
+	})
+	if err != nil {
+		return fmt.Errorf("while scheduling job %s: %w", j.Name, err)
+	}
+
+	e.id = id
+	s.entries = append(s.entries, e)
+	return nil
+}
+
+func (s *Scheduler) Start() {
+	s.cron.Start()
+}
+
+func (s *Scheduler) Stop() {
+	<-s.cron.Stop().Done()
+}
+
+func (s *Scheduler) RunNow(ctx context.Context, name string) error {
+	for _, e := range s.entries {
+		if e.Name == name {
+			return s.run(ctx, e)
+		}
+	}
+
+	return fmt.Errorf("job %s not found", name)
+}
+
+func (s *Scheduler) run(ctx context.Context, e *entry) (err error) {
+	ctx, runID := logging.WithRun(ctx, e.Name)
+	ctx, span := tracer.Start(ctx, "Scheduler.run", trace.WithAttributes(
+		attribute.String("job.name", e.Name),
+		attribute.String("job.run_id", runID),
+	))
+	defer tracing.End(span, &err)
+
+	logger := logging.FromContext(ctx)
+	if !e.lock.TryLock() {
+		logger.Warn("skipping job because it is still running")
+		return nil
+	}
+
+	defer e.lock.Unlock()
+	e.running.Store(true)
+	defer e.running.Store(false)
+
+	run, err := s.db.CreateRun(ctx, job.Run{
+		Job:       e.Name,
+		StartedAt: s.now(),
+	})
+	if err != nil {
+		logger.Error("while recording start of job", "error", err)
+		return err
+	}
+
+	logger.Info("running job", "job_run_id", run.ID)
+	count, taskErr := e.Task(ctx)
+
+	run.EndedAt = s.now()
+	run.Count = count
+	duration := run.EndedAt.Sub(run.StartedAt)
+	if taskErr != nil {
+		run.Error = taskErr.Error()
+		logger.Error("job failed", "job_run_id", run.ID, "count", count, "duration", duration, "error", taskErr)
+	} else {
+		logger.Info("job finished", "job_run_id", run.ID, "count", count, "duration", duration)
+	}
+
+	finishCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), finishTimeout)
+	defer cancel()
+	if err := s.db.UpdateRun(finishCtx, run); err != nil {
+		logger.Error("while recording end of job", "job_run_id", run.ID, "error", err)
+		return err
+	}
+
+	return taskErr
+}
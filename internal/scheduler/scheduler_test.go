@@ -0,0 +1,143 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"jira-integration/pkg/job"
+	"sync"
+	"testing"
+)
+
+type (
+	memoryRunDatabase struct {
+		lock sync.Mutex
+		runs []job.Run
+	}
+)
+
+func (m *memoryRunDatabase) CreateRun(_ context.Context, run job.Run) (job.Run, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	run.ID = uint(len(m.runs) + 1)
+	m.runs = append(m.runs, run)
+	return run, nil
+}
+
+func (m *memoryRunDatabase) UpdateRun(ctx context.Context, run job.Run) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.runs[run.ID-1] = run
+	return nil
+}
+
+func (m *memoryRunDatabase) GetLastRun(_ context.Context, jobName string) (job.Run, bool, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	for i := len(m.runs) - 1; i >= 0; i-- {
+		if m.runs[i].Job == jobName {
+			return m.runs[i], true, nil
+		}
+	}
+
+	return job.Run{}, false, nil
+}
+
+func TestScheduler_RunNow(t *testing.T) {
+	tests := []struct {
+		name      string
+		task      Task
+		wantCount int
+		wantError string
+	}{
+		{
+			name: "record the count of a successful run",
+			task: func(_ context.Context) (int, error) {
+				return 3, nil
+			},
+			wantCount: 3,
+		},
+		{
+			name: "record the error of a failed run",
+			task: func(_ context.Context) (int, error) {
+				return 1, errors.New("boom")
+			},
+			wantCount: 1,
+			wantError: "boom",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := &memoryRunDatabase{}
+			s := NewScheduler(db)
+			if err := s.Add(context.Background(), Job{Name: "job", Schedule: "@hourly", Task: tt.task}); err != nil {
+				t.Fatalf("Add() error = %v", err)
+			}
+
+			_ = s.RunNow(context.Background(), "job")
+
+			got, exists, _ := db.GetLastRun(context.Background(), "job")
+			if !exists {
+				t.Fatalf("RunNow() did not record the run")
+			}
+			if got.Count != tt.wantCount || got.Error != tt.wantError || got.Running() {
+				t.Errorf("RunNow() recorded = %+v, want count %d and error %q", got, tt.wantCount, tt.wantError)
+			}
+		})
+	}
+}
+
+func TestScheduler_RunNow_SkipOverlapping(t *testing.T) {
+	db := &memoryRunDatabase{}
+	started := make(chan struct{})
+	release := make(chan struct{})
+
+	s := NewScheduler(db)
+	_ = s.Add(context.Background(), Job{Name: "job", Schedule: "@hourly", Task: func(_ context.Context) (int, error) {
+		close(started)
+		<-release
+		return 0, nil
+	}})
+
+	done := make(chan struct{})
+	go func() {
+		_ = s.RunNow(context.Background(), "job")
+		close(done)
+	}()
+
+	<-started
+	_ = s.RunNow(context.Background(), "job")
+	close(release)
+	<-done
+
+	if len(db.runs) != 1 {
+		t.Errorf("RunNow() recorded %d runs, want 1", len(db.runs))
+	}
+}
+
+func TestScheduler_RunNow_Cancelled(t *testing.T) {
+	db := &memoryRunDatabase{}
+	ctx, cancel := context.WithCancel(context.Background())
+
+	s := NewScheduler(db)
+	_ = s.Add(ctx, Job{Name: "job", Schedule: "@hourly", Task: func(ctx context.Context) (int, error) {
+		cancel()
+		<-ctx.Done()
+		return 2, ctx.Err()
+	}})
+
+	if err := s.RunNow(ctx, "job"); !errors.Is(err, context.Canceled) {
+		t.Fatalf("RunNow() error = %v, want %v", err, context.Canceled)
+	}
+
+	got, _, _ := db.GetLastRun(context.Background(), "job")
+	if got.Running() || got.Count != 2 || got.Error != context.Canceled.Error() {
+		t.Errorf("RunNow() recorded = %+v, want a closed run with the cancellation", got)
+	}
+}
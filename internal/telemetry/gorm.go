@@ -0,0 +1,61 @@
+package telemetry
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const startedAtKey = "telemetry:started_at"
+
+type (
+	// gormPlugin times the creates, updates, deletes and raw statements, the
+	// ways the repository writes.
+	gormPlugin struct {
+		metrics *Metrics
+	}
+)
+
+// GormPlugin observes the latency of the database writes.
+func (m *Metrics) GormPlugin() gorm.Plugin {
+	return gormPlugin{metrics: m}
+}
+
+func (p gormPlugin) Name() string {
+	return "telemetry"
+}
+
+func (p gormPlugin) Initialize(db *gorm.DB) error {
+	callbacks := db.Callback()
+	for _, err := range []error{
+		callbacks.Create().Before("gorm:create").Register("telemetry:before_create", start),
+		callbacks.Create().After("gorm:create").Register("telemetry:after_create", p.observe("create")),
+		callbacks.Update().Before("gorm:update").Register("telemetry:before_update", start),
+		callbacks.Update().After("gorm:update").Register("telemetry:after_update", p.observe("update")),
+		callbacks.Delete().Before("gorm:delete").Register("telemetry:before_delete", start),
+		callbacks.Delete().After("gorm:delete").Register("telemetry:after_delete", p.observe("delete")),
+		callbacks.Raw().Before("gorm:raw").Register("telemetry:before_raw", start),
+		callbacks.Raw().After("gorm:raw").Register("telemetry:after_raw", p.observe("raw")),
+	} {
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (p gormPlugin) observe(operation string) func(db *gorm.DB) {
+	return func(db *gorm.DB) {
+		startedAt, ok := db.InstanceGet(startedAtKey)
+		if !ok {
+			return
+		}
+
+		p.metrics.ObserveDatabaseWrite(db.Statement.Table, operation, time.Since(startedAt.(time.Time)))
+	}
+}
+
+func start(db *gorm.DB) {
+	db.InstanceSet(startedAtKey, time.Now())
+}
@@ -0,0 +1,190 @@
+package telemetry
+
+import (
+	"context"
+	"fmt"
+	"jira-integration/pkg/issue"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/prometheus/client_golang/prometheus/push"
+)
+
+const (
+	namespace = "jira_integration"
+
+	// PushJob is the job name the metrics of one-shot runs are pushed under,
+	// grouped by command.
+	PushJob = "jira-integration"
+)
+
+type (
+	// Metrics are the Prometheus collectors of the ingestion. Its methods
+	// fit the jira.Observer and usecase.IssueObserver hooks.
+	Metrics struct {
+		registry            *prometheus.Registry
+		jiraRequests        *prometheus.CounterVec
+		jiraRequestDuration *prometheus.HistogramVec
+		jiraRetries         *prometheus.CounterVec
+		rateLimitWait       prometheus.Counter
+		issues              *prometheus.CounterVec
+		dbWriteDuration     *prometheus.HistogramVec
+		lastSuccess         *prometheus.GaugeVec
+		mutex               sync.Mutex
+		watermark           time.Time
+		now                 func() time.Time
+	}
+)
+
+func NewMetrics() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		jiraRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "jira_requests_total",
+			Help:      "Requests sent to Jira by endpoint, method and status code, counting every retried attempt.",
+		}, []string{"endpoint", "method", "status"}),
+		jiraRequestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "jira_request_duration_seconds",
+			Help:      "Latency of the requests sent to Jira by endpoint and method.",
+			Buckets:   prometheus.ExponentialBuckets(0.05, 2, 10),
+		}, []string{"endpoint", "method"}),
+		jiraRetries: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "jira_retries_total",
+			Help:      "Requests to Jira retried by endpoint and the status code that caused the retry.",
+		}, []string{"endpoint", "status"}),
+		rateLimitWait: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "jira_rate_limit_wait_seconds_total",
+			Help:      "Time spent waiting for Jira after being rate limited.",
+		}),
+		issues: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "issues_total",
+			Help:      "Issues streamed by outcome: fetched, skipped for being up to date, or failed.",
+		}, []string{"outcome"}),
+		dbWriteDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "db_write_duration_seconds",
+			Help:      "Latency of the database writes by table and operation.",
+			Buckets:   prometheus.ExponentialBuckets(0.001, 2, 12),
+		}, []string{"table", "operation"}),
+		lastSuccess: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "sync_last_success_timestamp_seconds",
+			Help:      "Unix time of the last successful run of each job or command.",
+		}, []string{"job"}),
+		now: time.Now,
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.jiraRequests,
+		m.jiraRequestDuration,
+		m.jiraRetries,
+		m.rateLimitWait,
+		m.issues,
+		m.dbWriteDuration,
+		m.lastSuccess,
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "sync_watermark_timestamp_seconds",
+			Help:      "Unix time of the newest issue update known to be stored.",
+		}, func() float64 {
+			return seconds(m.Watermark())
+		}),
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "sync_lag_seconds",
+			Help:      "Time since the newest issue update known to be stored, zero before the first sync.",
+		}, m.lag),
+	)
+
+	return m
+}
+
+func (m *Metrics) ObserveRequest(endpoint, method string, statusCode int, duration time.Duration) {
+	m.jiraRequests.WithLabelValues(endpoint, method, strconv.Itoa(statusCode)).Inc()
+	m.jiraRequestDuration.WithLabelValues(endpoint, method).Observe(duration.Seconds())
+}
+
+func (m *Metrics) ObserveRetry(endpoint string, statusCode int, wait time.Duration) {
+	m.jiraRetries.WithLabelValues(endpoint, strconv.Itoa(statusCode)).Inc()
+	if statusCode == http.StatusTooManyRequests {
+		m.rateLimitWait.Add(wait.Seconds())
+	}
+}
+
+// ObserveIssue counts the outcome and moves the watermark forward with the
+// issues that are stored up to date.
+func (m *Metrics) ObserveIssue(_ context.Context, stamp issue.Stamp, outcome string) {
+	m.issues.WithLabelValues(outcome).Inc()
+	if outcome != issue.OutcomeFailed {
+		m.SetWatermark(stamp.UpdatedAt)
+	}
+}
+
+func (m *Metrics) ObserveDatabaseWrite(table, operation string, duration time.Duration) {
+	m.dbWriteDuration.WithLabelValues(table, operation).Observe(duration.Seconds())
+}
+
+// ObserveRun records the end of a job or command, keeping the time of the
+// last one that succeeded.
+func (m *Metrics) ObserveRun(job string, err error) {
+	if err == nil {
+		m.lastSuccess.WithLabelValues(job).Set(seconds(m.now()))
+	}
+}
+
+// SetWatermark keeps the newest of the current and the given update times.
+func (m *Metrics) SetWatermark(updatedAt time.Time) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if updatedAt.After(m.watermark) {
+		m.watermark = updatedAt
+	}
+}
+
+func (m *Metrics) Watermark() time.Time {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return m.watermark
+}
+
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{Registry: m.registry})
+}
+
+// Push replaces the metrics of the command in the Pushgateway at url.
+func (m *Metrics) Push(ctx context.Context, url, command string) error {
+	if err := push.New(url, PushJob).Grouping("command", command).Gatherer(m.registry).PushContext(ctx); err != nil {
+		return fmt.Errorf("while pushing metrics to %s: %w", url, err)
+	}
+
+	return nil
+}
+
+func (m *Metrics) lag() float64 {
+	watermark := m.Watermark()
+	if watermark.IsZero() {
+		return 0
+	}
+
+	return m.now().Sub(watermark).Seconds()
+}
+
+func seconds(t time.Time) float64 {
+	if t.IsZero() {
+		return 0
+	}
+
+	return float64(t.UnixMilli()) / 1000
+}
@@ -0,0 +1,85 @@
+package telemetry
+
+import (
+	"context"
+	"io"
+	"jira-integration/internal/jira"
+	"jira-integration/internal/jira/fake"
+	"jira-integration/pkg/issue"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMetrics(t *testing.T) {
+	server := fake.NewServer(fake.Seed{
+		Sprints: []jira.Sprint{{ID: 38, Name: "Sprint 2", State: "active", OriginBoardID: 5}},
+	}, fake.WithFaults(fake.Faults{RateLimited: 1, RetryAfter: time.Second}))
+	defer server.Close()
+
+	metrics := NewMetrics()
+	now := time.Date(2024, 3, 25, 12, 0, 0, 0, time.UTC)
+	metrics.now = func() time.Time { return now }
+
+	client := jira.NewClient(server.URL, jira.Credentials{}, &http.Client{}).WithObserver(metrics)
+	if _, err := client.GetSprint(context.Background(), 38); err != nil {
+		t.Fatalf("GetSprint() error = %v", err)
+	}
+
+	metrics.ObserveIssue(context.Background(), issue.Stamp{UpdatedAt: now.Add(-time.Hour)}, issue.OutcomeFetched)
+	metrics.ObserveIssue(context.Background(), issue.Stamp{UpdatedAt: now.Add(-2 * time.Hour)}, issue.OutcomeSkipped)
+	metrics.ObserveIssue(context.Background(), issue.Stamp{UpdatedAt: now}, issue.OutcomeFailed)
+	metrics.ObserveRun("fetch", nil)
+	metrics.ObserveRun("sync sprints", context.Canceled)
+
+	recorder := httptest.NewRecorder()
+	metrics.Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	body, _ := io.ReadAll(recorder.Body)
+
+	tests := []struct {
+		name string
+		want string
+	}{
+		{
+			name: "count every attempt by endpoint and status",
+			want: `jira_integration_jira_requests_total{endpoint="/rest/agile/1.0/sprint/{id}",method="GET",status="429"} 1`,
+		},
+		{
+			name: "count the successful attempt",
+			want: `jira_integration_jira_requests_total{endpoint="/rest/agile/1.0/sprint/{id}",method="GET",status="200"} 1`,
+		},
+		{
+			name: "count retries",
+			want: `jira_integration_jira_retries_total{endpoint="/rest/agile/1.0/sprint/{id}",status="429"} 1`,
+		},
+		{
+			name: "sum the rate limit waits",
+			want: `jira_integration_jira_rate_limit_wait_seconds_total 1`,
+		},
+		{
+			name: "count issues by outcome",
+			want: `jira_integration_issues_total{outcome="skipped"} 1`,
+		},
+		{
+			name: "keep the last success of each job",
+			want: `jira_integration_sync_last_success_timestamp_seconds{job="fetch"} 1.711368e+09`,
+		},
+		{
+			name: "measure the lag from the newest stored update",
+			want: `jira_integration_sync_lag_seconds 3600`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !strings.Contains(string(body), tt.want) {
+				t.Errorf("Handler() doesn't contain %s:\n%s", tt.want, body)
+			}
+		})
+	}
+
+	if strings.Contains(string(body), `job="sync sprints"`) {
+		t.Errorf("Handler() has the last success of a failed job")
+	}
+}
@@ -0,0 +1,110 @@
+package telemetry
+
+import (
+	"context"
+	"fmt"
+	"jira-integration/internal/jira"
+	"jira-integration/internal/jira/fake"
+	"jira-integration/pkg/issue"
+	"jira-integration/usecase"
+	"net/http"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+type (
+	memoryDatabase struct {
+		issues map[uint]issue.Issue
+	}
+)
+
+func (m *memoryDatabase) GetByID(_ context.Context, issueID uint) (issue.Stamp, bool, error) {
+	i, exists := m.issues[issueID]
+	return i.Stamp, exists, nil
+}
+
+func (m *memoryDatabase) GetStatuses(context.Context) (issue.Statuses, error) {
+	return nil, nil
+}
+
+func (m *memoryDatabase) CreateIssue(_ context.Context, i issue.Issue) error {
+	m.issues[i.ID] = i
+	return nil
+}
+
+func (m *memoryDatabase) UpdateIssue(_ context.Context, i issue.Issue) error {
+	m.issues[i.ID] = i
+	return nil
+}
+
+func TestSetupTracing(t *testing.T) {
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "")
+	t.Setenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT", "")
+	before := otel.GetTracerProvider()
+	shutdown, err := SetupTracing(context.Background())
+	if err != nil || shutdown(context.Background()) != nil {
+		t.Fatalf("SetupTracing() error = %v", err)
+	}
+	if otel.GetTracerProvider() != before {
+		t.Errorf("SetupTracing() replaced the provider without an endpoint")
+	}
+}
+
+func TestTracing(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	defer otel.SetTracerProvider(previous)
+
+	seed := fake.Seed{}
+	for n := 1; n <= 3; n++ {
+		seed.Issues = append(seed.Issues, jira.Issue{ID: fmt.Sprint(10000 + n), Key: fmt.Sprintf("PAY-%d", n)})
+	}
+
+	server := fake.NewServer(seed, fake.WithPageSize(2))
+	defer server.Close()
+
+	db := &memoryDatabase{issues: map[uint]issue.Issue{}}
+	client := jira.NewClient(server.URL, jira.Credentials{}, &http.Client{})
+	fetch := usecase.NewFetchUseCase(client, db)
+	if err := usecase.NewStreamUseCase(client, fetch.Execute, db).Execute(context.Background(), "project = PAY"); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	spans := exporter.GetSpans()
+	names := map[string]int{}
+	parents := map[string]string{}
+	byID := map[string]string{}
+	for _, span := range spans {
+		names[span.Name]++
+		byID[span.SpanContext.SpanID().String()] = span.Name
+	}
+
+	for _, span := range spans {
+		parents[span.Name] = byID[span.Parent.SpanID().String()]
+	}
+
+	tests := []struct {
+		name       string
+		wantCount  int
+		wantParent string
+	}{
+		{name: "StreamUseCase.Execute", wantCount: 1},
+		{name: "StreamUseCase.searchPage", wantCount: 2, wantParent: "StreamUseCase.Execute"},
+		{name: "jira.SearchIssuesByJQL", wantCount: 2, wantParent: "StreamUseCase.searchPage"},
+		{name: "FetchUseCase.Execute", wantCount: 3, wantParent: "StreamUseCase.Execute"},
+		{name: "jira.GetIssueByID", wantCount: 3, wantParent: "FetchUseCase.Execute"},
+		{name: "jira.GetIssueChangelog", wantCount: 3, wantParent: "FetchUseCase.Execute"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if names[tt.name] != tt.wantCount || parents[tt.name] != tt.wantParent {
+				t.Errorf("span %s: %d spans with parent %q, want %d with parent %q", tt.name, names[tt.name], parents[tt.name], tt.wantCount, tt.wantParent)
+			}
+		})
+	}
+}
@@ -0,0 +1,353 @@
+package analytics
+
+import (
+	"jira-integration/pkg/issue"
+	"slices"
+	"sort"
+	"time"
+)
+
+type (
+	// StatusSet matches a status either by its name or by its category key,
+	// so the same set works across workflows with different status names.
+	StatusSet struct {
+		Names      []string `json:"names,omitempty"`
+		Categories []string `json:"categories,omitempty"`
+	}
+
+	// Config tells which statuses start and finish the work, and which of
+	// the ones in between are active work rather than waiting.
+	Config struct {
+		Start    StatusSet `json:"start"`
+		Done     StatusSet `json:"done"`
+		Active   StatusSet `json:"active"`
+		Calendar Calendar  `json:"-"`
+	}
+
+	// StatusDuration is the time spent in a status, both in calendar time and
+	// in business time according to the configured calendar.
+	StatusDuration struct {
+		Status           string        `json:"status"`
+		Category         string        `json:"category,omitempty"`
+		Visits           int           `json:"visits"`
+		Duration         time.Duration `json:"duration"`
+		BusinessDuration time.Duration `json:"business_duration"`
+	}
+
+	// IssueStatusDuration is a row of the time in status breakdown.
+	IssueStatusDuration struct {
+		IssueID   uint   `json:"issue_id"`
+		IssueKey  string `json:"issue_key"`
+		Project   string `json:"project"`
+		IssueType string `json:"issue_type"`
+		StatusDuration
+	}
+
+	Metrics struct {
+		IssueID      uint             `json:"issue_id"`
+		CreatedAt    time.Time        `json:"created_at"`
+		StartedAt    time.Time        `json:"started_at,omitempty"`
+		DoneAt       time.Time        `json:"done_at,omitempty"`
+		LeadTime     time.Duration    `json:"lead_time,omitempty"`
+		CycleTime    time.Duration    `json:"cycle_time,omitempty"`
+		ActiveTime   time.Duration    `json:"active_time,omitempty"`
+		WaitingTime  time.Duration    `json:"waiting_time,omitempty"`
+		BlockedTime  time.Duration    `json:"blocked_time,omitempty"`
+		Reopened     int              `json:"reopened"`
+		TimeInStatus []StatusDuration `json:"time_in_status"`
+		ComputedAt   time.Time        `json:"computed_at"`
+	}
+
+	// WorkItem is an issue with its stored metrics, as read by reports that
+	// don't need the whole changelog.
+	WorkItem struct {
+		IssueID        uint          `json:"issue_id"`
+		Key            string        `json:"key"`
+		Summary        string        `json:"summary"`
+		Project        string        `json:"project"`
+		IssueType      string        `json:"issue_type"`
+		Status         string        `json:"status"`
+		StatusCategory string        `json:"status_category,omitempty"`
+		Assignee       string        `json:"assignee,omitempty"`
+		StoryPoints    *uint         `json:"story_points,omitempty"`
+		ParentKey      string        `json:"parent_key,omitempty"`
+		SprintID       uint          `json:"sprint_id,omitempty"`
+		SprintName     string        `json:"sprint_name,omitempty"`
+		StartedAt      time.Time     `json:"started_at,omitempty"`
+		DoneAt         time.Time     `json:"done_at,omitempty"`
+		CycleTime      time.Duration `json:"cycle_time,omitempty"`
+		ActiveTime     time.Duration `json:"active_time,omitempty"`
+		WaitingTime    time.Duration `json:"waiting_time,omitempty"`
+		BlockedTime    time.Duration `json:"blocked_time,omitempty"`
+	}
+
+	Period struct {
+		From time.Time
+		To   time.Time
+	}
+
+	// Segment is a period the issue spent in a single status. The last
+	// segment of an open issue ends at the computation time.
+	Segment struct {
+		Status   string
+		Category string
+		From     time.Time
+		To       time.Time
+	}
+
+	Calculator struct {
+		config Config
+	}
+)
+
+func DefaultConfig() Config {
+	return Config{
+		Start:    StatusSet{Categories: []string{issue.StatusCategoryInProgress}},
+		Done:     StatusSet{Categories: []string{issue.StatusCategoryDone}},
+		Active:   StatusSet{Categories: []string{issue.StatusCategoryInProgress}},
+		Calendar: DefaultCalendar(),
+	}
+}
+
+func NewCalculator(config Config) *Calculator {
+	return &Calculator{
+		config: config,
+	}
+}
+
+func (c Calculator) Config() Config {
+	return c.config
+}
+
+func (s StatusSet) Match(status, category string) bool {
+	return slices.Contains(s.Names, status) || (category != "" && slices.Contains(s.Categories, category))
+}
+
+func (s StatusSet) IsEmpty() bool {
+	return len(s.Names) == 0 && len(s.Categories) == 0
+}
+
+// Transitions returns the status transitions of the issue sorted by time.
+func Transitions(i issue.Issue) []issue.Changelog {
+	output := make([]issue.Changelog, 0, len(i.Changelog))
+	for _, c := range i.Changelog {
+		if c.IsStatus() {
+			output = append(output, c)
+		}
+	}
+
+	sort.SliceStable(output, func(a, b int) bool {
+		return output[a].CreatedAt.Before(output[b].CreatedAt)
+	})
+
+	return output
+}
+
+// Segments rebuilds the status timeline of the issue from its creation up to
+// now. The initial status is the origin of the first transition, or the
+// current status when the issue never moved.
+func Segments(i issue.Issue, now time.Time) []Segment {
+	transitions := Transitions(i)
+	if len(transitions) == 0 {
+		return []Segment{{Status: i.Status, Category: i.StatusCategory, From: i.CreatedAt, To: now}}
+	}
+
+	output := make([]Segment, 0, len(transitions)+1)
+	current := Segment{Status: transitions[0].From, Category: transitions[0].FromCategory, From: i.CreatedAt}
+	for _, t := range transitions {
+		current.To = t.CreatedAt
+		output = append(output, current)
+		current = Segment{Status: t.To, Category: t.ToCategory, From: t.CreatedAt}
+	}
+
+	current.To = now
+	return append(output, current)
+}
+
+func (p Period) Duration() time.Duration {
+	if p.To.Before(p.From) {
+		return 0
+	}
+
+	return p.To.Sub(p.From)
+}
+
+// Intersect returns the overlap of both periods, if any.
+func (p Period) Intersect(other Period) (Period, bool) {
+	from, to := p.From, p.To
+	if other.From.After(from) {
+		from = other.From
+	}
+
+	if other.To.Before(to) {
+		to = other.To
+	}
+
+	return Period{From: from, To: to}, to.After(from)
+}
+
+func (s Segment) Duration() time.Duration {
+	if s.To.Before(s.From) {
+		return 0
+	}
+
+	return s.To.Sub(s.From)
+}
+
+// Compute calculates the flow metrics of the issue. An issue is done only
+// when its current status is a done one, and it is done at the last time it
+// got there, so reopened issues count the whole rework. Issues that jumped
+// straight to done without passing by a start status are considered started
+// when they were finished.
+func (c Calculator) Compute(i issue.Issue, now time.Time) Metrics {
+	segments := Segments(i, now)
+	output := Metrics{
+		IssueID:    i.ID,
+		CreatedAt:  i.CreatedAt,
+		ComputedAt: now,
+	}
+
+	wasDone := false
+	for _, segment := range segments {
+		isDone := c.config.Done.Match(segment.Status, segment.Category)
+		if wasDone && !isDone {
+			output.Reopened++
+			output.DoneAt = time.Time{}
+		}
+
+		if isDone && !wasDone {
+			output.DoneAt = segment.From
+		}
+
+		if output.StartedAt.IsZero() && (isDone || c.config.Start.Match(segment.Status, segment.Category)) {
+			output.StartedAt = segment.From
+		}
+
+		wasDone = isDone
+	}
+
+	if !output.DoneAt.IsZero() {
+		output.LeadTime = output.DoneAt.Sub(output.CreatedAt)
+		if !output.StartedAt.IsZero() {
+			output.CycleTime = output.DoneAt.Sub(output.StartedAt)
+		}
+	}
+
+	output.TimeInStatus = c.timeInStatus(segments, output.DoneAt)
+	if !output.StartedAt.IsZero() {
+		end := output.DoneAt
+		if end.IsZero() {
+			end = now
+		}
+
+		output.ActiveTime, output.WaitingTime, output.BlockedTime = c.flow(segments, FlaggedPeriods(i, now), output.StartedAt, end)
+	}
+
+	return output
+}
+
+// FlowEfficiency is the share of the time spent in active statuses.
+func (m Metrics) FlowEfficiency() float64 {
+	return Efficiency(m.ActiveTime, m.WaitingTime)
+}
+
+func Efficiency(active, waiting time.Duration) float64 {
+	if active+waiting <= 0 {
+		return 0
+	}
+
+	return float64(active) / float64(active+waiting)
+}
+
+// FlaggedPeriods rebuilds when the issue was flagged as blocked. An issue
+// flagged without any history was flagged when it was created.
+func FlaggedPeriods(i issue.Issue, now time.Time) []Period {
+	var changes []issue.Changelog
+	for _, c := range i.Changelog {
+		if c.Field == issue.FieldFlagged {
+			changes = append(changes, c)
+		}
+	}
+
+	sort.SliceStable(changes, func(a, b int) bool {
+		return changes[a].CreatedAt.Before(changes[b].CreatedAt)
+	})
+
+	var output []Period
+	var since time.Time
+	flagged := false
+	if len(changes) == 0 && i.Flagged {
+		return []Period{{From: i.CreatedAt, To: now}}
+	}
+
+	for _, c := range changes {
+		switch {
+		case c.To != "" && !flagged:
+			flagged, since = true, c.CreatedAt
+		case c.To == "" && flagged:
+			flagged = false
+			output = append(output, Period{From: since, To: c.CreatedAt})
+		}
+	}
+
+	if flagged {
+		output = append(output, Period{From: since, To: now})
+	}
+
+	return output
+}
+
+// flow splits the time between start and end into active and waiting time.
+// Time flagged as blocked is waiting even in an active status.
+func (c Calculator) flow(segments []Segment, flagged []Period, start, end time.Time) (time.Duration, time.Duration, time.Duration) {
+	var active, blocked time.Duration
+	window := Period{From: start, To: end}
+	for _, segment := range segments {
+		period, overlaps := window.Intersect(Period{From: segment.From, To: segment.To})
+		if !overlaps || !c.config.Active.Match(segment.Status, segment.Category) {
+			continue
+		}
+
+		active += period.Duration()
+		for _, f := range flagged {
+			if blockedPeriod, overlaps := period.Intersect(f); overlaps {
+				active -= blockedPeriod.Duration()
+			}
+		}
+	}
+
+	for _, f := range flagged {
+		if period, overlaps := window.Intersect(f); overlaps {
+			blocked += period.Duration()
+		}
+	}
+
+	return active, window.Duration() - active, blocked
+}
+
+// timeInStatus sums the time spent in each status in order of first visit.
+// The time an issue rests in its final done status is not accounted.
+func (c Calculator) timeInStatus(segments []Segment, doneAt time.Time) []StatusDuration {
+	var output []StatusDuration
+	positions := map[string]int{}
+	for index, segment := range segments {
+		duration := segment.Duration()
+		businessDuration := c.config.Calendar.BusinessDuration(segment.From, segment.To)
+		if index == len(segments)-1 && !doneAt.IsZero() {
+			duration, businessDuration = 0, 0
+		}
+
+		position, exists := positions[segment.Status]
+		if !exists {
+			position = len(output)
+			positions[segment.Status] = position
+			output = append(output, StatusDuration{Status: segment.Status, Category: segment.Category})
+		}
+
+		output[position].Visits++
+		output[position].Duration += duration
+		output[position].BusinessDuration += businessDuration
+	}
+
+	return output
+}
@@ -0,0 +1,209 @@
+package analytics
+
+import (
+	"jira-integration/pkg/issue"
+	"reflect"
+	"testing"
+	"time"
+)
+
+var (
+	day0 = time.Date(2024, 3, 11, 9, 0, 0, 0, time.UTC)
+)
+
+func day(n int) time.Time {
+	return day0.AddDate(0, 0, n)
+}
+
+func transition(from, fromCategory, to, toCategory string, at time.Time) issue.Changelog {
+	return issue.Changelog{
+		From:         from,
+		To:           to,
+		FromCategory: fromCategory,
+		ToCategory:   toCategory,
+		CreatedAt:    at,
+	}
+}
+
+func TestCalculator_Compute(t *testing.T) {
+	const (
+		todo       = issue.StatusCategoryToDo
+		inProgress = issue.StatusCategoryInProgress
+		done       = issue.StatusCategoryDone
+	)
+
+	tests := []struct {
+		name   string
+		config Config
+		issue  issue.Issue
+		now    time.Time
+		want   Metrics
+	}{
+		{
+			name:   "compute lead and cycle time of a regular flow",
+			config: DefaultConfig(),
+			issue: issue.Issue{
+				Stamp: issue.Stamp{ID: 1, CreatedAt: day(0)},
+				Changelog: []issue.Changelog{
+					transition("In Review", inProgress, "Done", done, day(6)),
+					transition("To Do", todo, "In Progress", inProgress, day(2)),
+					transition("In Progress", inProgress, "In Review", inProgress, day(5)),
+				},
+			},
+			now: day(10),
+			want: Metrics{
+				IssueID:    1,
+				CreatedAt:  day(0),
+				StartedAt:  day(2),
+				DoneAt:     day(6),
+				LeadTime:   6 * 24 * time.Hour,
+				CycleTime:  4 * 24 * time.Hour,
+				ActiveTime: 4 * 24 * time.Hour,
+				TimeInStatus: []StatusDuration{
+					{Status: "To Do", Category: todo, Visits: 1, Duration: 2 * 24 * time.Hour, BusinessDuration: 18 * time.Hour},
+					{Status: "In Progress", Category: inProgress, Visits: 1, Duration: 3 * 24 * time.Hour, BusinessDuration: 27 * time.Hour},
+					{Status: "In Review", Category: inProgress, Visits: 1, Duration: 24 * time.Hour},
+					{Status: "Done", Category: done, Visits: 1},
+				},
+				ComputedAt: day(10),
+			},
+		},
+		{
+			name:   "use the last done transition of reopened issues",
+			config: DefaultConfig(),
+			issue: issue.Issue{
+				Stamp: issue.Stamp{ID: 2, CreatedAt: day(0)},
+				Changelog: []issue.Changelog{
+					transition("To Do", todo, "In Progress", inProgress, day(1)),
+					transition("In Progress", inProgress, "Done", done, day(2)),
+					transition("Done", done, "In Progress", inProgress, day(4)),
+					transition("In Progress", inProgress, "Done", done, day(5)),
+				},
+			},
+			now: day(10),
+			want: Metrics{
+				IssueID:     2,
+				CreatedAt:   day(0),
+				StartedAt:   day(1),
+				DoneAt:      day(5),
+				LeadTime:    5 * 24 * time.Hour,
+				CycleTime:   4 * 24 * time.Hour,
+				ActiveTime:  2 * 24 * time.Hour,
+				WaitingTime: 2 * 24 * time.Hour,
+				Reopened:    1,
+				TimeInStatus: []StatusDuration{
+					{Status: "To Do", Category: todo, Visits: 1, Duration: 24 * time.Hour, BusinessDuration: 9 * time.Hour},
+					{Status: "In Progress", Category: inProgress, Visits: 2, Duration: 2 * 24 * time.Hour, BusinessDuration: 18 * time.Hour},
+					{Status: "Done", Category: done, Visits: 2, Duration: 2 * 24 * time.Hour, BusinessDuration: 18 * time.Hour},
+				},
+				ComputedAt: day(10),
+			},
+		},
+		{
+			name:   "consider issues that skipped the start statuses started when done",
+			config: DefaultConfig(),
+			issue: issue.Issue{
+				Stamp: issue.Stamp{ID: 3, CreatedAt: day(0)},
+				Changelog: []issue.Changelog{
+					transition("To Do", todo, "Done", done, day(3)),
+				},
+			},
+			now: day(10),
+			want: Metrics{
+				IssueID:   3,
+				CreatedAt: day(0),
+				StartedAt: day(3),
+				DoneAt:    day(3),
+				LeadTime:  3 * 24 * time.Hour,
+				TimeInStatus: []StatusDuration{
+					{Status: "To Do", Category: todo, Visits: 1, Duration: 3 * 24 * time.Hour, BusinessDuration: 27 * time.Hour},
+					{Status: "Done", Category: done, Visits: 1},
+				},
+				ComputedAt: day(10),
+			},
+		},
+		{
+			name: "match statuses by name and keep open issues undone",
+			config: Config{
+				Start: StatusSet{Names: []string{"In Development"}},
+				Done:  StatusSet{Names: []string{"Released"}},
+			},
+			issue: issue.Issue{
+				Stamp: issue.Stamp{ID: 4, CreatedAt: day(0)},
+				Changelog: []issue.Changelog{
+					transition("Backlog", "", "In Development", "", day(1)),
+					transition("In Development", "", "Done", "", day(2)),
+				},
+			},
+			now: day(4),
+			want: Metrics{
+				IssueID:     4,
+				CreatedAt:   day(0),
+				StartedAt:   day(1),
+				WaitingTime: 3 * 24 * time.Hour,
+				TimeInStatus: []StatusDuration{
+					{Status: "Backlog", Visits: 1, Duration: 24 * time.Hour},
+					{Status: "In Development", Visits: 1, Duration: 24 * time.Hour},
+					{Status: "Done", Visits: 1, Duration: 2 * 24 * time.Hour},
+				},
+				ComputedAt: day(4),
+			},
+		},
+		{
+			name:   "count flagged time in active statuses as blocked waiting time",
+			config: DefaultConfig(),
+			issue: issue.Issue{
+				Stamp: issue.Stamp{ID: 6, CreatedAt: day(0)},
+				Changelog: []issue.Changelog{
+					transition("To Do", todo, "In Progress", inProgress, day(1)),
+					{Field: issue.FieldFlagged, To: "Impediment", CreatedAt: day(2)},
+					{Field: issue.FieldFlagged, From: "Impediment", CreatedAt: day(3)},
+					transition("In Progress", inProgress, "Done", done, day(5)),
+				},
+			},
+			now: day(10),
+			want: Metrics{
+				IssueID:     6,
+				CreatedAt:   day(0),
+				StartedAt:   day(1),
+				DoneAt:      day(5),
+				LeadTime:    5 * 24 * time.Hour,
+				CycleTime:   4 * 24 * time.Hour,
+				ActiveTime:  3 * 24 * time.Hour,
+				WaitingTime: 24 * time.Hour,
+				BlockedTime: 24 * time.Hour,
+				TimeInStatus: []StatusDuration{
+					{Status: "To Do", Category: todo, Visits: 1, Duration: 24 * time.Hour, BusinessDuration: 9 * time.Hour},
+					{Status: "In Progress", Category: inProgress, Visits: 1, Duration: 4 * 24 * time.Hour, BusinessDuration: 36 * time.Hour},
+					{Status: "Done", Category: done, Visits: 1},
+				},
+				ComputedAt: day(10),
+			},
+		},
+		{
+			name:   "account the whole life of issues that never moved",
+			config: DefaultConfig(),
+			issue: issue.Issue{
+				Stamp:          issue.Stamp{ID: 5, CreatedAt: day(0)},
+				Status:         "To Do",
+				StatusCategory: todo,
+			},
+			now: day(1),
+			want: Metrics{
+				IssueID:   5,
+				CreatedAt: day(0),
+				TimeInStatus: []StatusDuration{
+					{Status: "To Do", Category: todo, Visits: 1, Duration: 24 * time.Hour, BusinessDuration: 9 * time.Hour},
+				},
+				ComputedAt: day(1),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewCalculator(tt.config).Compute(tt.issue, tt.now); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Compute() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
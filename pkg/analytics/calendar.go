@@ -0,0 +1,160 @@
+package analytics
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+type (
+	// Calendar describes working hours, used to tell how much of the time an
+	// issue spent in a status happened while people were actually working.
+	Calendar struct {
+		Location     *time.Location
+		WorkdayStart time.Duration
+		WorkdayEnd   time.Duration
+		Weekdays     []time.Weekday
+		Holidays     map[string]bool
+	}
+)
+
+func DefaultCalendar() Calendar {
+	return Calendar{
+		Location:     time.UTC,
+		WorkdayStart: 9 * time.Hour,
+		WorkdayEnd:   18 * time.Hour,
+		Weekdays:     []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday},
+		Holidays:     map[string]bool{},
+	}
+}
+
+// NewCalendar builds a calendar from its textual configuration: an IANA time
+// zone, the workday bounds as "15:04", weekday names and holidays as
+// "2006-01-02". Empty values keep the defaults.
+func NewCalendar(timezone, workdayStart, workdayEnd string, weekdays, holidays []string) (Calendar, error) {
+	calendar := DefaultCalendar()
+	if timezone != "" {
+		location, err := time.LoadLocation(timezone)
+		if err != nil {
+			return Calendar{}, err
+		}
+
+		calendar.Location = location
+	}
+
+	var err error
+	if workdayStart != "" {
+		if calendar.WorkdayStart, err = parseClock(workdayStart); err != nil {
+			return Calendar{}, err
+		}
+	}
+
+	if workdayEnd != "" {
+		if calendar.WorkdayEnd, err = parseClock(workdayEnd); err != nil {
+			return Calendar{}, err
+		}
+	}
+
+	if calendar.WorkdayEnd <= calendar.WorkdayStart {
+		return Calendar{}, fmt.Errorf("workday end %s must be after its start %s", workdayEnd, workdayStart)
+	}
+
+	if len(weekdays) != 0 {
+		calendar.Weekdays = nil
+		for _, name := range weekdays {
+			weekday, err := parseWeekday(name)
+			if err != nil {
+				return Calendar{}, err
+			}
+
+			calendar.Weekdays = append(calendar.Weekdays, weekday)
+		}
+	}
+
+	for _, holiday := range holidays {
+		if _, err := time.Parse(time.DateOnly, holiday); err != nil {
+			return Calendar{}, fmt.Errorf("invalid holiday %q: %w", holiday, err)
+		}
+
+		calendar.Holidays[holiday] = true
+	}
+
+	return calendar, nil
+}
+
+func (c Calendar) IsWorkday(t time.Time) bool {
+	t = t.In(c.Location)
+	return slices.Contains(c.Weekdays, t.Weekday()) && !c.Holidays[t.Format(time.DateOnly)]
+}
+
+// BusinessDuration returns how much of the [from, to) interval falls within
+// working hours of working days.
+func (c Calendar) BusinessDuration(from, to time.Time) time.Duration {
+	if !to.After(from) || len(c.Weekdays) == 0 || c.Location == nil {
+		return 0
+	}
+
+	from, to = from.In(c.Location), to.In(c.Location)
+	var output time.Duration
+	for day := startOfDay(from); day.Before(to); day = day.AddDate(0, 0, 1) {
+		if !c.IsWorkday(day) {
+			continue
+		}
+
+		start := maxTime(from, wallClock(day, c.WorkdayStart))
+		end := minTime(to, wallClock(day, c.WorkdayEnd))
+		if end.After(start) {
+			output += end.Sub(start)
+		}
+	}
+
+	return output
+}
+
+func startOfDay(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+}
+
+// wallClock returns the time of day on the given day. Adding the duration to
+// midnight would be an hour off on days the clocks change.
+func wallClock(day time.Time, timeOfDay time.Duration) time.Time {
+	year, month, date := day.Date()
+	return time.Date(year, month, date, int(timeOfDay/time.Hour), int(timeOfDay%time.Hour/time.Minute), 0, 0, day.Location())
+}
+
+func parseClock(value string) (time.Duration, error) {
+	parsed, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time of day %q: %w", value, err)
+	}
+
+	return time.Duration(parsed.Hour())*time.Hour + time.Duration(parsed.Minute())*time.Minute, nil
+}
+
+func parseWeekday(value string) (time.Weekday, error) {
+	for weekday := time.Sunday; weekday <= time.Saturday; weekday++ {
+		if strings.EqualFold(weekday.String(), value) || strings.EqualFold(weekday.String()[:3], value) {
+			return weekday, nil
+		}
+	}
+
+	return 0, fmt.Errorf("invalid weekday %q", value)
+}
+
+func maxTime(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+
+	return b
+}
+
+func minTime(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+
+	return b
+}
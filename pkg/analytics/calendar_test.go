@@ -0,0 +1,102 @@
+package analytics
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCalendar_BusinessDuration(t *testing.T) {
+	saoPaulo, err := time.LoadLocation("America/Sao_Paulo")
+	if err != nil {
+		t.Skip("time zone database not available")
+	}
+
+	calendar, err := NewCalendar("America/Sao_Paulo", "09:00", "18:00", nil, []string{"2024-03-13"})
+	if err != nil {
+		t.Fatalf("NewCalendar() error = %v", err)
+	}
+
+	tests := []struct {
+		name string
+		from time.Time
+		to   time.Time
+		want time.Duration
+	}{
+		{
+			name: "count only the working hours of a single day",
+			from: time.Date(2024, 3, 11, 8, 0, 0, 0, saoPaulo),
+			to:   time.Date(2024, 3, 11, 20, 0, 0, 0, saoPaulo),
+			want: 9 * time.Hour,
+		},
+		{
+			name: "skip holidays and weekends",
+			from: time.Date(2024, 3, 12, 17, 0, 0, 0, saoPaulo),
+			to:   time.Date(2024, 3, 18, 10, 0, 0, 0, saoPaulo),
+			want: time.Hour + 9*time.Hour + 9*time.Hour + time.Hour,
+		},
+		{
+			name: "convert instants to the calendar time zone",
+			from: time.Date(2024, 3, 11, 11, 0, 0, 0, time.UTC),
+			to:   time.Date(2024, 3, 11, 12, 30, 0, 0, time.UTC),
+			want: 30 * time.Minute,
+		},
+		{
+			name: "return zero for inverted intervals",
+			from: time.Date(2024, 3, 11, 12, 0, 0, 0, saoPaulo),
+			to:   time.Date(2024, 3, 11, 11, 0, 0, 0, saoPaulo),
+			want: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := calendar.BusinessDuration(tt.from, tt.to); got != tt.want {
+				t.Errorf("BusinessDuration() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCalendar_BusinessDuration_DaylightSaving(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip("time zone database not available")
+	}
+
+	calendar, err := NewCalendar("America/New_York", "09:00", "18:00", []string{"Sunday"}, nil)
+	if err != nil {
+		t.Fatalf("NewCalendar() error = %v", err)
+	}
+
+	tests := []struct {
+		name string
+		from time.Time
+		to   time.Time
+		want time.Duration
+	}{
+		{
+			name: "start the workday at its wall clock time when clocks spring forward",
+			from: time.Date(2024, 3, 10, 9, 0, 0, 0, newYork),
+			to:   time.Date(2024, 3, 10, 12, 0, 0, 0, newYork),
+			want: 3 * time.Hour,
+		},
+		{
+			name: "end the workday at its wall clock time when clocks fall back",
+			from: time.Date(2024, 11, 3, 17, 0, 0, 0, newYork),
+			to:   time.Date(2024, 11, 3, 20, 0, 0, 0, newYork),
+			want: time.Hour,
+		},
+		{
+			name: "count a whole workday on a transition day",
+			from: time.Date(2024, 3, 10, 0, 0, 0, 0, newYork),
+			to:   time.Date(2024, 3, 11, 0, 0, 0, 0, newYork),
+			want: 9 * time.Hour,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := calendar.BusinessDuration(tt.from, tt.to); got != tt.want {
+				t.Errorf("BusinessDuration() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
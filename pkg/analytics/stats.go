@@ -0,0 +1,98 @@
+package analytics
+
+import (
+	"math"
+	"slices"
+	"sort"
+	"time"
+)
+
+type (
+	DurationStats struct {
+		Mean time.Duration `json:"mean"`
+		P50  time.Duration `json:"p50"`
+		P85  time.Duration `json:"p85"`
+		P95  time.Duration `json:"p95"`
+	}
+
+	StatusSummary struct {
+		Project  string        `json:"project"`
+		Status   string        `json:"status"`
+		Category string        `json:"category,omitempty"`
+		Issues   int           `json:"issues"`
+		Calendar DurationStats `json:"calendar"`
+		Business DurationStats `json:"business"`
+	}
+)
+
+// Percentile returns the nearest-rank percentile (0-100) of the values.
+func Percentile(values []time.Duration, percentile float64) time.Duration {
+	if len(values) == 0 {
+		return 0
+	}
+
+	sorted := slices.Clone(values)
+	slices.Sort(sorted)
+	rank := int(math.Ceil(percentile / 100 * float64(len(sorted))))
+	rank = max(1, min(rank, len(sorted)))
+	return sorted[rank-1]
+}
+
+func NewDurationStats(values []time.Duration) DurationStats {
+	if len(values) == 0 {
+		return DurationStats{}
+	}
+
+	var total time.Duration
+	for _, value := range values {
+		total += value
+	}
+
+	return DurationStats{
+		Mean: total / time.Duration(len(values)),
+		P50:  Percentile(values, 50),
+		P85:  Percentile(values, 85),
+		P95:  Percentile(values, 95),
+	}
+}
+
+// SummarizeStatusDurations groups the breakdown by project and status,
+// putting the statuses where issues wait the longest first.
+func SummarizeStatusDurations(rows []IssueStatusDuration) []StatusSummary {
+	type key struct{ project, status string }
+	calendar := map[key][]time.Duration{}
+	business := map[key][]time.Duration{}
+	categories := map[key]string{}
+	for _, row := range rows {
+		k := key{row.Project, row.Status}
+		calendar[k] = append(calendar[k], row.Duration)
+		business[k] = append(business[k], row.BusinessDuration)
+		categories[k] = row.Category
+	}
+
+	output := make([]StatusSummary, 0, len(calendar))
+	for k, durations := range calendar {
+		output = append(output, StatusSummary{
+			Project:  k.project,
+			Status:   k.status,
+			Category: categories[k],
+			Issues:   len(durations),
+			Calendar: NewDurationStats(durations),
+			Business: NewDurationStats(business[k]),
+		})
+	}
+
+	sort.Slice(output, func(i, j int) bool {
+		if output[i].Business.P85 != output[j].Business.P85 {
+			return output[i].Business.P85 > output[j].Business.P85
+		}
+
+		if output[i].Project != output[j].Project {
+			return output[i].Project < output[j].Project
+		}
+
+		return output[i].Status < output[j].Status
+	})
+
+	return output
+}
@@ -0,0 +1,144 @@
+package forecast
+
+import (
+	"errors"
+	"math"
+	"math/rand/v2"
+	"sort"
+	"time"
+)
+
+const (
+	// maxWeeks bounds the simulations of how long the remaining items take,
+	// so weeks without throughput can't loop forever.
+	maxWeeks = 520
+	week     = 7 * 24 * time.Hour
+)
+
+var (
+	NoThroughputErr = errors.New("no throughput to forecast from")
+
+	DefaultPercentiles = []int{50, 70, 85, 95}
+)
+
+type (
+	// Outcome is the answer of a forecast at a confidence level: how many
+	// items will be done, or in how many weeks and by which date.
+	Outcome struct {
+		Percentile int       `json:"percentile"`
+		Items      int       `json:"items,omitempty"`
+		Weeks      int       `json:"weeks,omitempty"`
+		Date       time.Time `json:"date,omitempty"`
+	}
+
+	Forecast struct {
+		Trials     int       `json:"trials"`
+		Throughput []int     `json:"throughput"`
+		From       time.Time `json:"from"`
+		Until      time.Time `json:"until,omitempty"`
+		Remaining  int       `json:"remaining,omitempty"`
+		Outcomes   []Outcome `json:"outcomes"`
+	}
+
+	// Simulator draws weekly throughput samples at random to play out the
+	// future many times.
+	Simulator struct {
+		samples []int
+		trials  int
+		rand    *rand.Rand
+	}
+)
+
+// WeeklyThroughput counts the dates within each of the weeks after from.
+func WeeklyThroughput(done []time.Time, from time.Time, weeks int) []int {
+	output := make([]int, weeks, weeks)
+	for _, d := range done {
+		if d.Before(from) {
+			continue
+		}
+
+		if index := int(d.Sub(from) / week); index < weeks {
+			output[index]++
+		}
+	}
+
+	return output
+}
+
+func NewSimulator(samples []int, trials int, seed uint64) (*Simulator, error) {
+	var total int
+	for _, sample := range samples {
+		total += sample
+	}
+
+	if total == 0 {
+		return nil, NoThroughputErr
+	}
+
+	return &Simulator{
+		samples: samples,
+		trials:  max(trials, 1),
+		rand:    rand.New(rand.NewPCG(seed, seed)),
+	}, nil
+}
+
+// HowMany forecasts how many items will be done from now until the date.
+// The last partial week counts in proportion to the days left in it.
+func (s Simulator) HowMany(now, until time.Time, percentiles []int) Forecast {
+	weeks := until.Sub(now).Hours() / week.Hours()
+	outcomes := make([]int, s.trials, s.trials)
+	for trial := range outcomes {
+		var items float64
+		for remaining := weeks; remaining > 0; remaining-- {
+			items += float64(s.draw()) * math.Min(remaining, 1)
+		}
+
+		outcomes[trial] = int(items)
+	}
+
+	sort.Ints(outcomes)
+	output := Forecast{Trials: s.trials, Throughput: s.samples, From: now, Until: until}
+	for _, p := range percentiles {
+		// at p% confidence at least this many items are done
+		output.Outcomes = append(output.Outcomes, Outcome{Percentile: p, Items: percentile(outcomes, 100-p)})
+	}
+
+	return output
+}
+
+// When forecasts in how many weeks the remaining items will be done.
+func (s Simulator) When(now time.Time, remaining int, percentiles []int) Forecast {
+	outcomes := make([]int, s.trials, s.trials)
+	for trial := range outcomes {
+		weeks, done := 0, 0
+		for done < remaining && weeks < maxWeeks {
+			done += s.draw()
+			weeks++
+		}
+
+		outcomes[trial] = weeks
+	}
+
+	sort.Ints(outcomes)
+	output := Forecast{Trials: s.trials, Throughput: s.samples, From: now, Remaining: remaining}
+	for _, p := range percentiles {
+		weeks := percentile(outcomes, p)
+		output.Outcomes = append(output.Outcomes, Outcome{Percentile: p, Weeks: weeks, Date: now.AddDate(0, 0, 7*weeks)})
+	}
+
+	return output
+}
+
+func (s Simulator) draw() int {
+	return s.samples[s.rand.IntN(len(s.samples))]
+}
+
+// percentile uses the nearest rank over sorted values.
+func percentile(sorted []int, p int) int {
+	if len(sorted) == 0 {
+		return 0
+	}
+
+	rank := int(math.Ceil(float64(p)/100*float64(len(sorted)))) - 1
+	return sorted[min(max(rank, 0), len(sorted)-1)]
+}
@@ -0,0 +1,68 @@
+package forecast
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+var (
+	now = time.Date(2024, 3, 11, 0, 0, 0, 0, time.UTC)
+)
+
+func TestWeeklyThroughput(t *testing.T) {
+	from := now.AddDate(0, 0, -21)
+	done := []time.Time{
+		from.AddDate(0, 0, -1),
+		from,
+		from.AddDate(0, 0, 6),
+		from.AddDate(0, 0, 15),
+		now,
+	}
+
+	if got, want := WeeklyThroughput(done, from, 3), []int{2, 0, 1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("WeeklyThroughput() = %v, want %v", got, want)
+	}
+}
+
+func TestSimulator(t *testing.T) {
+	if _, err := NewSimulator([]int{0, 0}, 100, 1); err != NoThroughputErr {
+		t.Errorf("NewSimulator() error = %v, want %v", err, NoThroughputErr)
+	}
+
+	simulator, err := NewSimulator([]int{5}, 100, 1)
+	if err != nil {
+		t.Fatalf("NewSimulator() error = %v", err)
+	}
+
+	howMany := simulator.HowMany(now, now.AddDate(0, 0, 17), []int{50, 85})
+	if got, want := howMany.Outcomes, []Outcome{{Percentile: 50, Items: 12}, {Percentile: 85, Items: 12}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("HowMany() = %v, want %v", got, want)
+	}
+
+	when := simulator.When(now, 11, []int{85})
+	if got, want := when.Outcomes, []Outcome{{Percentile: 85, Weeks: 3, Date: now.AddDate(0, 0, 21)}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("When() = %v, want %v", got, want)
+	}
+}
+
+func TestSimulator_Percentiles(t *testing.T) {
+	simulator, err := NewSimulator([]int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, 5000, 42)
+	if err != nil {
+		t.Fatalf("NewSimulator() error = %v", err)
+	}
+
+	outcomes := simulator.HowMany(now, now.AddDate(0, 0, 28), DefaultPercentiles).Outcomes
+	for i := 1; i < len(outcomes); i++ {
+		if outcomes[i].Items > outcomes[i-1].Items {
+			t.Errorf("HowMany() at %d%% = %d, more than %d at %d%%", outcomes[i].Percentile, outcomes[i].Items, outcomes[i-1].Items, outcomes[i-1].Percentile)
+		}
+	}
+
+	when := simulator.When(now, 40, DefaultPercentiles).Outcomes
+	for i := 1; i < len(when); i++ {
+		if when[i].Weeks < when[i-1].Weeks {
+			t.Errorf("When() at %d%% = %d weeks, less than %d at %d%%", when[i].Percentile, when[i].Weeks, when[i-1].Weeks, when[i-1].Percentile)
+		}
+	}
+}
@@ -6,6 +6,23 @@ import (
 	"time"
 )
 
+const (
+	FieldStatus      = "status"
+	FieldSprint      = "sprint"
+	FieldStoryPoints = "story_points"
+	FieldFlagged     = "flagged"
+
+	StatusCategoryToDo       = "new"
+	StatusCategoryInProgress = "indeterminate"
+	StatusCategoryDone       = "done"
+
+	// Outcomes of an issue streamed by a fetch: fetched, skipped for being up
+	// to date, or failed.
+	OutcomeFetched = "fetched"
+	OutcomeSkipped = "skipped"
+	OutcomeFailed  = "failed"
+)
+
 type (
 	Label string
 
@@ -15,11 +32,16 @@ type (
 	}
 
 	Changelog struct {
-		ID        uint      `json:"id"`
-		Author    string    `json:"author"`
-		From      string    `json:"from"`
-		To        string    `json:"to"`
-		CreatedAt time.Time `json:"created_at"`
+		ID           uint      `json:"id"`
+		Field        string    `json:"field"`
+		Author       string    `json:"author"`
+		From         string    `json:"from"`
+		To           string    `json:"to"`
+		FromID       string    `json:"from_id,omitempty"`
+		ToID         string    `json:"to_id,omitempty"`
+		FromCategory string    `json:"from_category,omitempty"`
+		ToCategory   string    `json:"to_category,omitempty"`
+		CreatedAt    time.Time `json:"created_at"`
 	}
 
 	Sprint struct {
@@ -30,6 +52,44 @@ type (
 		StartedAt   time.Time `json:"start_date"`
 		EndedAt     time.Time `json:"end_date"`
 		CompletedAt time.Time `json:"complete_date,omitempty"`
+		BoardID     uint      `json:"board_id,omitempty"`
+		BoardName   string    `json:"board_name,omitempty"`
+	}
+
+	Board struct {
+		ID          uint          `json:"id"`
+		Name        string        `json:"name"`
+		Type        string        `json:"type"`
+		ProjectKey  string        `json:"project_key,omitempty"`
+		ProjectName string        `json:"project_name,omitempty"`
+		Columns     []BoardColumn `json:"columns,omitempty"`
+	}
+
+	BoardColumn struct {
+		Name      string   `json:"name"`
+		Position  int      `json:"position"`
+		StatusIDs []string `json:"status_ids"`
+	}
+
+	Status struct {
+		ID           string `json:"id"`
+		Name         string `json:"name"`
+		Description  string `json:"description,omitempty"`
+		CategoryID   uint   `json:"category_id"`
+		CategoryKey  string `json:"category_key"`
+		CategoryName string `json:"category_name"`
+	}
+
+	Statuses []Status
+
+	Version struct {
+		ID          uint      `json:"id"`
+		Name        string    `json:"name"`
+		Description string    `json:"description"`
+		Project     string    `json:"project"`
+		Archived    bool      `json:"archived"`
+		Released    bool      `json:"released"`
+		ReleasedAt  time.Time `json:"release_date,omitempty"`
 	}
 
 	Account struct {
@@ -50,20 +110,23 @@ type (
 
 	Issue struct {
 		Stamp
-		Summary     string      `json:"summary"`
-		Status      string      `json:"status"`
-		IssueType   string      `json:"issue_type"`
-		Project     string      `json:"project"`
-		Parent      *Issue      `json:"parent,omitempty"`
-		Sprint      *Sprint     `json:"sprint,omitempty"`
-		Labels      []Label     `json:"labels,omitempty"`
-		Assignee    *Account    `json:"assignee,omitempty"`
-		Reporter    Account     `json:"reporter"`
-		StoryPoints *uint       `json:"story_points,omitempty"`
-		Products    []Product   `json:"products,omitempty"`
-		FixVersion  string      `json:"fix_version,omitempty"`
-		Locality    string      `json:"locality"`
-		Changelog   []Changelog `json:"changelog,omitempty"`
+		Summary        string      `json:"summary"`
+		Status         string      `json:"status"`
+		StatusID       string      `json:"status_id,omitempty"`
+		StatusCategory string      `json:"status_category,omitempty"`
+		IssueType      string      `json:"issue_type"`
+		Project        string      `json:"project"`
+		Parent         *Issue      `json:"parent,omitempty"`
+		Sprint         *Sprint     `json:"sprint,omitempty"`
+		Labels         []Label     `json:"labels,omitempty"`
+		Assignee       *Account    `json:"assignee,omitempty"`
+		Reporter       Account     `json:"reporter"`
+		StoryPoints    *uint       `json:"story_points,omitempty"`
+		Flagged        bool        `json:"flagged"`
+		Products       []Product   `json:"products,omitempty"`
+		FixVersion     string      `json:"fix_version,omitempty"`
+		Locality       string      `json:"locality"`
+		Changelog      []Changelog `json:"changelog,omitempty"`
 	}
 )
 
@@ -79,3 +142,18 @@ func (l Label) Hash() string {
 	hash := md5.Sum([]byte(l))
 	return hex.EncodeToString(hash[:])
 }
+
+func (s Statuses) Categories() map[string]string {
+	output := make(map[string]string, len(s))
+	for _, status := range s {
+		output[status.ID] = status.CategoryKey
+	}
+
+	return output
+}
+
+// IsStatus tells whether the changelog entry is a status transition. Entries
+// stored before other fields were tracked have no field and are all statuses.
+func (c Changelog) IsStatus() bool {
+	return c.Field == "" || c.Field == FieldStatus
+}
@@ -0,0 +1,24 @@
+package job
+
+import (
+	"time"
+)
+
+type (
+	Run struct {
+		ID        uint      `json:"id"`
+		Job       string    `json:"job"`
+		StartedAt time.Time `json:"started_at"`
+		EndedAt   time.Time `json:"ended_at,omitempty"`
+		Count     int       `json:"count"`
+		Error     string    `json:"error,omitempty"`
+	}
+)
+
+func (r Run) Running() bool {
+	return r.EndedAt.IsZero()
+}
+
+func (r Run) Failed() bool {
+	return r.Error != ""
+}
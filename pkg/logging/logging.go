@@ -0,0 +1,75 @@
+package logging
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+)
+
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+
+	RunIDKey = "run_id"
+	JobKey   = "job"
+)
+
+var (
+	InvalidFormatErr = errors.New("invalid log format")
+	InvalidLevelErr  = errors.New("invalid log level")
+)
+
+type (
+	loggerKey struct{}
+)
+
+// NewLogger writes records at or above the level, "debug", "info", "warn" or
+// "error", as text or JSON.
+func NewLogger(w io.Writer, format, level string) (*slog.Logger, error) {
+	var lvl slog.Level
+	if err := lvl.UnmarshalText([]byte(level)); err != nil {
+		return nil, fmt.Errorf("%w: %s", InvalidLevelErr, level)
+	}
+
+	options := &slog.HandlerOptions{Level: lvl}
+	switch strings.ToLower(format) {
+	case FormatText:
+		return slog.New(slog.NewTextHandler(w, options)), nil
+	case FormatJSON:
+		return slog.New(slog.NewJSONHandler(w, options)), nil
+	default:
+		return nil, fmt.Errorf("%w: %s", InvalidFormatErr, format)
+	}
+}
+
+// NewContext returns a copy of ctx carrying the logger.
+func NewContext(ctx context.Context, logger *slog.Logger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, logger)
+}
+
+// FromContext returns the logger carried by ctx, or the default one.
+func FromContext(ctx context.Context) *slog.Logger {
+	if logger, ok := ctx.Value(loggerKey{}).(*slog.Logger); ok {
+		return logger
+	}
+
+	return slog.Default()
+}
+
+// WithRun starts a run of the job: every record logged through the returned
+// context carries a new run id and the job name.
+func WithRun(ctx context.Context, job string) (context.Context, string) {
+	runID := NewRunID()
+	return NewContext(ctx, FromContext(ctx).With(RunIDKey, runID, JobKey, job)), runID
+}
+
+func NewRunID() string {
+	raw := make([]byte, 8)
+	_, _ = rand.Read(raw)
+	return hex.EncodeToString(raw)
+}
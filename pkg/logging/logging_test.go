@@ -0,0 +1,74 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestNewLogger(t *testing.T) {
+	tests := []struct {
+		name    string
+		format  string
+		level   string
+		want    string
+		wantErr error
+	}{
+		{
+			name:   "write json records with the run fields",
+			format: FormatJSON,
+			level:  "info",
+			want:   `"msg":"fetched issue","run_id":"*","job":"fetch","issue_key":"PAY-1"`,
+		},
+		{
+			name:   "write text records",
+			format: FormatText,
+			level:  "INFO",
+			want:   `msg="fetched issue" run_id=* job=fetch issue_key=PAY-1`,
+		},
+		{
+			name:   "drop records below the level",
+			format: FormatText,
+			level:  "warn",
+			want:   "",
+		},
+		{
+			name:    "fail on unknown formats",
+			format:  "xml",
+			level:   "info",
+			wantErr: InvalidFormatErr,
+		},
+		{
+			name:    "fail on unknown levels",
+			format:  FormatJSON,
+			level:   "verbose",
+			wantErr: InvalidLevelErr,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var output bytes.Buffer
+			logger, err := NewLogger(&output, tt.format, tt.level)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("NewLogger() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			ctx, runID := WithRun(NewContext(context.Background(), logger), "fetch")
+			FromContext(ctx).Info("fetched issue", "issue_key", "PAY-1")
+
+			want := strings.ReplaceAll(tt.want, "*", runID)
+			if got := output.String(); !strings.Contains(got, want) || (want == "" && got != "") {
+				t.Errorf("Info() wrote %s, want %s", got, want)
+			}
+			if tt.format == FormatJSON && !json.Valid(output.Bytes()) {
+				t.Errorf("Info() wrote invalid json %s", output.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package report
+
+import (
+	"jira-integration/pkg/analytics"
+	"sort"
+	"time"
+)
+
+const (
+	RiskOK       = "ok"
+	RiskWatch    = "watch"
+	RiskAtRisk   = "at_risk"
+	RiskCritical = "critical"
+	RiskUnknown  = "unknown"
+
+	BaselineTypeAndProject = "type_and_project"
+	BaselineProject        = "project"
+	BaselineAll            = "all"
+)
+
+type (
+	// AgingItem is a work in progress compared with the cycle time of the
+	// issues done before it. Baseline tells which history was used: the same
+	// issue type and project, the same project or everything.
+	AgingItem struct {
+		analytics.WorkItem
+		Age       time.Duration           `json:"age"`
+		CycleTime analytics.DurationStats `json:"cycle_time_percentiles"`
+		Samples   int                     `json:"samples"`
+		Baseline  string                  `json:"baseline"`
+		Risk      string                  `json:"risk"`
+	}
+)
+
+// Aging rates each item in progress by how its age compares with the 50th,
+// 85th and 95th percentiles of the cycle time of the done items, oldest
+// items first.
+func Aging(inProgress, done []analytics.WorkItem, now time.Time) []AgingItem {
+	byTypeAndProject := map[[2]string][]time.Duration{}
+	byProject := map[string][]time.Duration{}
+	var all []time.Duration
+	for _, item := range done {
+		if item.CycleTime <= 0 {
+			continue
+		}
+
+		key := [2]string{item.Project, item.IssueType}
+		byTypeAndProject[key] = append(byTypeAndProject[key], item.CycleTime)
+		byProject[item.Project] = append(byProject[item.Project], item.CycleTime)
+		all = append(all, item.CycleTime)
+	}
+
+	output := make([]AgingItem, len(inProgress), len(inProgress))
+	for i, item := range inProgress {
+		samples, baseline := byTypeAndProject[[2]string{item.Project, item.IssueType}], BaselineTypeAndProject
+		if len(samples) == 0 {
+			samples, baseline = byProject[item.Project], BaselineProject
+		}
+
+		if len(samples) == 0 {
+			samples, baseline = all, BaselineAll
+		}
+
+		aging := AgingItem{
+			WorkItem:  item,
+			Age:       now.Sub(item.StartedAt),
+			CycleTime: analytics.NewDurationStats(samples),
+			Samples:   len(samples),
+			Baseline:  baseline,
+		}
+
+		aging.Risk = risk(aging)
+		output[i] = aging
+	}
+
+	sort.SliceStable(output, func(a, b int) bool {
+		return output[a].Age > output[b].Age
+	})
+
+	return output
+}
+
+func risk(item AgingItem) string {
+	switch {
+	case item.Samples == 0:
+		return RiskUnknown
+	case item.Age >= item.CycleTime.P95:
+		return RiskCritical
+	case item.Age >= item.CycleTime.P85:
+		return RiskAtRisk
+	case item.Age >= item.CycleTime.P50:
+		return RiskWatch
+	default:
+		return RiskOK
+	}
+}
@@ -0,0 +1,51 @@
+package report
+
+import (
+	"jira-integration/pkg/analytics"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestAging(t *testing.T) {
+	days := func(n int) time.Duration {
+		return time.Duration(n) * 24 * time.Hour
+	}
+
+	var done []analytics.WorkItem
+	for n := 1; n <= 20; n++ {
+		done = append(done, analytics.WorkItem{Project: "P", IssueType: "Story", CycleTime: days(n)})
+	}
+
+	done = append(done, analytics.WorkItem{Project: "P", IssueType: "Bug", CycleTime: days(2)})
+	inProgress := []analytics.WorkItem{
+		{Key: "P-1", Project: "P", IssueType: "Story", StartedAt: day(-5)},
+		{Key: "P-2", Project: "P", IssueType: "Story", StartedAt: day(-12)},
+		{Key: "P-3", Project: "P", IssueType: "Story", StartedAt: day(-17)},
+		{Key: "P-4", Project: "P", IssueType: "Story", StartedAt: day(-19)},
+		{Key: "P-5", Project: "P", IssueType: "Bug", StartedAt: day(-1)},
+		{Key: "P-6", Project: "P", IssueType: "Task", StartedAt: day(-30)},
+	}
+
+	got := Aging(inProgress, done, day(0))
+
+	var keys, risks, baselines []string
+	for _, item := range got {
+		keys = append(keys, item.Key)
+		risks = append(risks, item.Risk)
+		baselines = append(baselines, item.Baseline)
+	}
+
+	if want := []string{"P-6", "P-4", "P-3", "P-2", "P-1", "P-5"}; !reflect.DeepEqual(keys, want) {
+		t.Errorf("keys = %v, want %v", keys, want)
+	}
+
+	if want := []string{RiskCritical, RiskCritical, RiskAtRisk, RiskWatch, RiskOK, RiskOK}; !reflect.DeepEqual(risks, want) {
+		t.Errorf("risks = %v, want %v", risks, want)
+	}
+
+	want := []string{BaselineProject, BaselineTypeAndProject, BaselineTypeAndProject, BaselineTypeAndProject, BaselineTypeAndProject, BaselineTypeAndProject}
+	if !reflect.DeepEqual(baselines, want) {
+		t.Errorf("baselines = %v, want %v", baselines, want)
+	}
+}
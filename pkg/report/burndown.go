@@ -0,0 +1,194 @@
+package report
+
+import (
+	"jira-integration/pkg/analytics"
+	"jira-integration/pkg/issue"
+	"sort"
+	"time"
+)
+
+const (
+	ScopeAdded     = "added"
+	ScopeRemoved   = "removed"
+	ScopeEstimated = "estimated"
+)
+
+type (
+	// BurndownPoint is the sprint at the end of one day. The actual values
+	// are nil for days that haven't happened yet.
+	BurndownPoint struct {
+		Date      time.Time `json:"date"`
+		Scope     *float64  `json:"scope"`
+		Completed *float64  `json:"completed"`
+		Remaining *float64  `json:"remaining"`
+		Ideal     float64   `json:"ideal"`
+		Added     float64   `json:"added"`
+		Removed   float64   `json:"removed"`
+	}
+
+	ScopeChange struct {
+		At       time.Time `json:"at"`
+		IssueID  uint      `json:"issue_id"`
+		IssueKey string    `json:"issue_key"`
+		Kind     string    `json:"kind"`
+		Points   float64   `json:"points"`
+	}
+
+	Burndown struct {
+		Sprint  issue.Sprint    `json:"sprint"`
+		Points  []BurndownPoint `json:"points"`
+		Changes []ScopeChange   `json:"changes"`
+	}
+
+	BurndownBuilder struct {
+		done analytics.StatusSet
+	}
+)
+
+func NewBurndownBuilder(done analytics.StatusSet) *BurndownBuilder {
+	return &BurndownBuilder{
+		done: done,
+	}
+}
+
+// Build samples the sprint once a day from its start to its planned end,
+// replaying the status, sprint and story points changes of its issues.
+func (b BurndownBuilder) Build(s issue.Sprint, issues []issue.Issue, now time.Time) Burndown {
+	output := Burndown{Sprint: s}
+	if s.StartedAt.IsZero() {
+		return output
+	}
+
+	start, end := s.StartedAt, s.EndedAt
+	if end.IsZero() || end.Before(start) {
+		end = SprintEnd(s, now)
+	}
+
+	histories := make([]History, len(issues), len(issues))
+	for i, current := range issues {
+		histories[i] = NewHistory(current)
+		output.Changes = append(output.Changes, scopeChanges(histories[i], s.ID, start, end)...)
+	}
+
+	sort.SliceStable(output.Changes, func(a, c int) bool {
+		return output.Changes[a].At.Before(output.Changes[c].At)
+	})
+
+	dates := sampleDates(start, end)
+	committed, _ := b.scopeAt(histories, s.ID, start)
+	for i, date := range dates {
+		point := BurndownPoint{
+			Date:  date,
+			Ideal: ideal(committed, start, end, date),
+		}
+
+		if !date.After(now) {
+			scope, completed := b.scopeAt(histories, s.ID, date)
+			remaining := scope - completed
+			point.Scope, point.Completed, point.Remaining = &scope, &completed, &remaining
+		}
+
+		if i > 0 {
+			point.Added, point.Removed = changesBetween(output.Changes, dates[i-1], date)
+		}
+
+		output.Points = append(output.Points, point)
+	}
+
+	return output
+}
+
+// scopeAt sums the points of the issues in the sprint at t and of the ones
+// among them already done.
+func (b BurndownBuilder) scopeAt(histories []History, sprintID uint, t time.Time) (float64, float64) {
+	var scope, completed float64
+	for _, h := range histories {
+		if !h.InSprintAt(sprintID, t) {
+			continue
+		}
+
+		points := h.StoryPointsAt(t)
+		scope += points
+		if h.DoneAt(t, b.done) {
+			completed += points
+		}
+	}
+
+	return scope, completed
+}
+
+func scopeChanges(h History, sprintID uint, start, end time.Time) []ScopeChange {
+	var output []ScopeChange
+	i := h.Issue()
+	change := func(at time.Time, kind string, points float64) {
+		output = append(output, ScopeChange{At: at, IssueID: i.ID, IssueKey: i.Key, Kind: kind, Points: points})
+	}
+
+	if i.CreatedAt.After(start) && !i.CreatedAt.After(end) && h.InSprintAt(sprintID, i.CreatedAt) {
+		change(i.CreatedAt, ScopeAdded, h.StoryPointsAt(i.CreatedAt))
+	}
+
+	for _, c := range h.sprints {
+		if !c.CreatedAt.After(start) || c.CreatedAt.After(end) {
+			continue
+		}
+
+		was, is := containsSprint(c.FromID, sprintID), containsSprint(c.ToID, sprintID)
+		switch {
+		case !was && is:
+			change(c.CreatedAt, ScopeAdded, h.StoryPointsAt(c.CreatedAt))
+		case was && !is:
+			change(c.CreatedAt, ScopeRemoved, h.StoryPointsAt(c.CreatedAt))
+		}
+	}
+
+	for _, c := range h.storyPoints {
+		if c.CreatedAt.After(start) && !c.CreatedAt.After(end) && h.InSprintAt(sprintID, c.CreatedAt) {
+			change(c.CreatedAt, ScopeEstimated, parsePoints(c.To)-parsePoints(c.From))
+		}
+	}
+
+	return output
+}
+
+// changesBetween sums the scope added and removed within (from, to]. Estimate
+// changes count as added or removed according to their sign.
+func changesBetween(changes []ScopeChange, from, to time.Time) (float64, float64) {
+	var added, removed float64
+	for _, c := range changes {
+		if !c.At.After(from) || c.At.After(to) {
+			continue
+		}
+
+		switch {
+		case c.Kind == ScopeAdded:
+			added += c.Points
+		case c.Kind == ScopeRemoved:
+			removed += c.Points
+		case c.Points > 0:
+			added += c.Points
+		default:
+			removed -= c.Points
+		}
+	}
+
+	return added, removed
+}
+
+func sampleDates(start, end time.Time) []time.Time {
+	var output []time.Time
+	for date := start; date.Before(end); date = date.AddDate(0, 0, 1) {
+		output = append(output, date)
+	}
+
+	return append(output, end)
+}
+
+func ideal(committed float64, start, end, t time.Time) float64 {
+	total := end.Sub(start)
+	if total <= 0 {
+		return 0
+	}
+
+	return committed * (1 - float64(t.Sub(start))/float64(total))
+}
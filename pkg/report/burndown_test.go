@@ -0,0 +1,75 @@
+package report
+
+import (
+	"jira-integration/pkg/analytics"
+	"jira-integration/pkg/issue"
+	"reflect"
+	"testing"
+)
+
+func TestBurndownBuilder_Build(t *testing.T) {
+	sprint := issue.Sprint{ID: 10, StartedAt: day(0), EndedAt: day(4)}
+	inSprint := &issue.Sprint{ID: 10}
+	issues := []issue.Issue{
+		{
+			Stamp:          issue.Stamp{ID: 1, Key: "A", CreatedAt: day(-5)},
+			Status:         "Done",
+			StatusCategory: issue.StatusCategoryDone,
+			Sprint:         inSprint,
+			StoryPoints:    points(3),
+			Changelog: []issue.Changelog{
+				statusChange("To Do", "Done", issue.StatusCategoryDone, day(1).Add(-1)),
+			},
+		},
+		{
+			Stamp:          issue.Stamp{ID: 2, Key: "B", CreatedAt: day(-5)},
+			Status:         "To Do",
+			StatusCategory: issue.StatusCategoryToDo,
+			Sprint:         inSprint,
+			StoryPoints:    points(8),
+			Changelog: []issue.Changelog{
+				pointsChange("5", "8", day(2).Add(-1)),
+			},
+		},
+		{
+			Stamp:          issue.Stamp{ID: 3, Key: "C", CreatedAt: day(-5)},
+			Status:         "To Do",
+			StatusCategory: issue.StatusCategoryToDo,
+			Sprint:         inSprint,
+			StoryPoints:    points(2),
+			Changelog: []issue.Changelog{
+				sprintChange("", "10", day(2).Add(-1)),
+			},
+		},
+	}
+
+	got := NewBurndownBuilder(analytics.DefaultConfig().Done).Build(sprint, issues, day(3))
+
+	var remaining []any
+	var ideals, added []float64
+	for _, point := range got.Points {
+		ideals = append(ideals, point.Ideal)
+		added = append(added, point.Added)
+		if point.Remaining == nil {
+			remaining = append(remaining, nil)
+		} else {
+			remaining = append(remaining, *point.Remaining)
+		}
+	}
+
+	if want := []any{8.0, 5.0, 10.0, 10.0, nil}; !reflect.DeepEqual(remaining, want) {
+		t.Errorf("remaining = %v, want %v", remaining, want)
+	}
+
+	if want := []float64{8, 6, 4, 2, 0}; !reflect.DeepEqual(ideals, want) {
+		t.Errorf("ideal = %v, want %v", ideals, want)
+	}
+
+	if want := []float64{0, 0, 5, 0, 0}; !reflect.DeepEqual(added, want) {
+		t.Errorf("added = %v, want %v", added, want)
+	}
+
+	if len(got.Changes) != 2 || got.Changes[0].Kind != ScopeEstimated || got.Changes[1].Kind != ScopeAdded {
+		t.Errorf("changes = %+v", got.Changes)
+	}
+}
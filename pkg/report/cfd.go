@@ -0,0 +1,140 @@
+package report
+
+import (
+	"jira-integration/pkg/issue"
+	"sort"
+	"time"
+)
+
+const (
+	FlowByStatus   = "status"
+	FlowByCategory = "category"
+
+	unknownCategory = "unknown"
+)
+
+var (
+	// categoryOrder stacks done at the bottom of the diagram and to do on top.
+	categoryOrder = map[string]int{
+		issue.StatusCategoryDone:       0,
+		issue.StatusCategoryInProgress: 1,
+		issue.StatusCategoryToDo:       2,
+		unknownCategory:                3,
+	}
+)
+
+type (
+	CumulativeFlowDay struct {
+		Date   time.Time      `json:"date"`
+		Counts map[string]int `json:"counts"`
+	}
+
+	CumulativeFlow struct {
+		By      string              `json:"by"`
+		Columns []string            `json:"columns"`
+		Days    []CumulativeFlowDay `json:"days"`
+	}
+
+	// CumulativeFlowBuilder counts, at the end of each day of the range, how
+	// many issues were in each status or status category. Issues are added
+	// one at a time so they don't have to be held in memory.
+	CumulativeFlowBuilder struct {
+		by         string
+		dates      []time.Time
+		counts     []map[string]int
+		categories map[string]string
+	}
+)
+
+func NewCumulativeFlowBuilder(from, to time.Time, by string) *CumulativeFlowBuilder {
+	b := &CumulativeFlowBuilder{
+		by:         by,
+		categories: map[string]string{},
+	}
+
+	for date := from; !date.After(to); date = date.AddDate(0, 0, 1) {
+		b.dates = append(b.dates, date)
+		b.counts = append(b.counts, map[string]int{})
+	}
+
+	return b
+}
+
+func (b *CumulativeFlowBuilder) Add(i issue.Issue) {
+	h := NewHistory(i)
+	for index, date := range b.dates {
+		endOfDay := date.AddDate(0, 0, 1).Add(-time.Nanosecond)
+		if !h.Exists(endOfDay) {
+			continue
+		}
+
+		status, category := h.StatusAt(endOfDay)
+		if category == "" {
+			category = unknownCategory
+		}
+
+		column := category
+		if b.by == FlowByStatus {
+			column = status
+			if _, exists := b.categories[status]; !exists || b.categories[status] == unknownCategory {
+				b.categories[status] = category
+			}
+		}
+
+		b.counts[index][column]++
+	}
+}
+
+func (b *CumulativeFlowBuilder) Build() CumulativeFlow {
+	output := CumulativeFlow{
+		By:      b.by,
+		Columns: b.columns(),
+	}
+
+	for i, date := range b.dates {
+		output.Days = append(output.Days, CumulativeFlowDay{Date: date, Counts: b.counts[i]})
+	}
+
+	return output
+}
+
+func (b *CumulativeFlowBuilder) columns() []string {
+	seen := map[string]bool{}
+	for _, counts := range b.counts {
+		for column := range counts {
+			seen[column] = true
+		}
+	}
+
+	category := func(column string) string {
+		if b.by == FlowByStatus {
+			return b.categories[column]
+		}
+
+		return column
+	}
+
+	var output []string
+	for column := range seen {
+		output = append(output, column)
+	}
+
+	sort.Slice(output, func(i, j int) bool {
+		a, c := rank(category(output[i])), rank(category(output[j]))
+		if a != c {
+			return a < c
+		}
+
+		return output[i] < output[j]
+	})
+
+	return output
+}
+
+func rank(category string) int {
+	if r, exists := categoryOrder[category]; exists {
+		return r
+	}
+
+	return len(categoryOrder)
+}
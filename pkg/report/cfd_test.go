@@ -0,0 +1,77 @@
+package report
+
+import (
+	"jira-integration/pkg/issue"
+	"reflect"
+	"testing"
+)
+
+func TestCumulativeFlowBuilder_Build(t *testing.T) {
+	issues := []issue.Issue{
+		{
+			Stamp:          issue.Stamp{ID: 1, CreatedAt: day(-1)},
+			Status:         "Done",
+			StatusCategory: issue.StatusCategoryDone,
+			Changelog: []issue.Changelog{
+				{From: "To Do", FromCategory: issue.StatusCategoryToDo, To: "In Progress", ToCategory: issue.StatusCategoryInProgress, CreatedAt: day(1)},
+				{From: "In Progress", FromCategory: issue.StatusCategoryInProgress, To: "Done", ToCategory: issue.StatusCategoryDone, CreatedAt: day(2)},
+			},
+		},
+		{
+			Stamp:          issue.Stamp{ID: 2, CreatedAt: day(1)},
+			Status:         "To Do",
+			StatusCategory: issue.StatusCategoryToDo,
+		},
+	}
+
+	tests := []struct {
+		name        string
+		by          string
+		wantColumns []string
+		wantCounts  []map[string]int
+	}{
+		{
+			name:        "Should count issues per category",
+			by:          FlowByCategory,
+			wantColumns: []string{issue.StatusCategoryDone, issue.StatusCategoryInProgress, issue.StatusCategoryToDo},
+			wantCounts: []map[string]int{
+				{issue.StatusCategoryToDo: 1},
+				{issue.StatusCategoryInProgress: 1, issue.StatusCategoryToDo: 1},
+				{issue.StatusCategoryDone: 1, issue.StatusCategoryToDo: 1},
+			},
+		},
+		{
+			name:        "Should count issues per status ordered by category",
+			by:          FlowByStatus,
+			wantColumns: []string{"Done", "In Progress", "To Do"},
+			wantCounts: []map[string]int{
+				{"To Do": 1},
+				{"In Progress": 1, "To Do": 1},
+				{"Done": 1, "To Do": 1},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			builder := NewCumulativeFlowBuilder(day(0), day(2), tt.by)
+			for _, i := range issues {
+				builder.Add(i)
+			}
+
+			got := builder.Build()
+			if !reflect.DeepEqual(got.Columns, tt.wantColumns) {
+				t.Errorf("Columns = %v, want %v", got.Columns, tt.wantColumns)
+			}
+
+			var counts []map[string]int
+			for _, d := range got.Days {
+				counts = append(counts, d.Counts)
+			}
+
+			if !reflect.DeepEqual(counts, tt.wantCounts) {
+				t.Errorf("Counts = %v, want %v", counts, tt.wantCounts)
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package report
+
+import (
+	"jira-integration/pkg/analytics"
+	"sort"
+	"time"
+)
+
+const (
+	EfficiencyByIssue  = "issue"
+	EfficiencyByEpic   = "epic"
+	EfficiencyBySprint = "sprint"
+)
+
+type (
+	// FlowEfficiency is the share of active time over the cycle time of an
+	// issue or of all the issues of an epic or sprint.
+	FlowEfficiency struct {
+		Group       string        `json:"group"`
+		SprintID    uint          `json:"sprint_id,omitempty"`
+		Issues      int           `json:"issues"`
+		ActiveTime  time.Duration `json:"active_time"`
+		WaitingTime time.Duration `json:"waiting_time"`
+		BlockedTime time.Duration `json:"blocked_time"`
+		Efficiency  float64       `json:"efficiency"`
+	}
+)
+
+// FlowEfficiencyBy aggregates the items per issue, epic or sprint, ordered
+// from the least efficient. Sprints are told apart by ID, since boards may
+// name them alike. Items without a parent or sprint are grouped under an
+// empty name.
+func FlowEfficiencyBy(items []analytics.WorkItem, by string) []FlowEfficiency {
+	type key struct {
+		group    string
+		sprintID uint
+	}
+
+	positions := map[key]int{}
+	var output []FlowEfficiency
+	for _, item := range items {
+		k := key{group: item.Key}
+		switch by {
+		case EfficiencyByEpic:
+			k = key{group: item.ParentKey}
+		case EfficiencyBySprint:
+			k = key{group: item.SprintName, sprintID: item.SprintID}
+		}
+
+		position, exists := positions[k]
+		if !exists {
+			position = len(output)
+			positions[k] = position
+			output = append(output, FlowEfficiency{Group: k.group, SprintID: k.sprintID})
+		}
+
+		output[position].Issues++
+		output[position].ActiveTime += item.ActiveTime
+		output[position].WaitingTime += item.WaitingTime
+		output[position].BlockedTime += item.BlockedTime
+	}
+
+	for i := range output {
+		output[i].Efficiency = analytics.Efficiency(output[i].ActiveTime, output[i].WaitingTime)
+	}
+
+	sort.SliceStable(output, func(a, b int) bool {
+		return output[a].Efficiency < output[b].Efficiency
+	})
+
+	return output
+}
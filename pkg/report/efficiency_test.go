@@ -0,0 +1,55 @@
+package report
+
+import (
+	"jira-integration/pkg/analytics"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestFlowEfficiencyBy(t *testing.T) {
+	items := []analytics.WorkItem{
+		{Key: "PAY-1", ParentKey: "PAY-100", SprintID: 1, SprintName: "Sprint 1", ActiveTime: 6 * time.Hour, WaitingTime: 2 * time.Hour},
+		{Key: "PAY-2", ParentKey: "PAY-100", SprintID: 1, SprintName: "Sprint 1", ActiveTime: 2 * time.Hour, WaitingTime: 6 * time.Hour, BlockedTime: 4 * time.Hour},
+		{Key: "OPS-1", SprintID: 2, SprintName: "Sprint 1", ActiveTime: time.Hour, WaitingTime: 3 * time.Hour, BlockedTime: 3 * time.Hour},
+	}
+
+	tests := []struct {
+		name string
+		by   string
+		want []FlowEfficiency
+	}{
+		{
+			name: "rank issues from the least efficient",
+			by:   EfficiencyByIssue,
+			want: []FlowEfficiency{
+				{Group: "PAY-2", Issues: 1, ActiveTime: 2 * time.Hour, WaitingTime: 6 * time.Hour, BlockedTime: 4 * time.Hour, Efficiency: 0.25},
+				{Group: "OPS-1", Issues: 1, ActiveTime: time.Hour, WaitingTime: 3 * time.Hour, BlockedTime: 3 * time.Hour, Efficiency: 0.25},
+				{Group: "PAY-1", Issues: 1, ActiveTime: 6 * time.Hour, WaitingTime: 2 * time.Hour, Efficiency: 0.75},
+			},
+		},
+		{
+			name: "sum the active, waiting and blocked time of an epic",
+			by:   EfficiencyByEpic,
+			want: []FlowEfficiency{
+				{Group: "", Issues: 1, ActiveTime: time.Hour, WaitingTime: 3 * time.Hour, BlockedTime: 3 * time.Hour, Efficiency: 0.25},
+				{Group: "PAY-100", Issues: 2, ActiveTime: 8 * time.Hour, WaitingTime: 8 * time.Hour, BlockedTime: 4 * time.Hour, Efficiency: 0.5},
+			},
+		},
+		{
+			name: "tell apart sprints named alike on different boards",
+			by:   EfficiencyBySprint,
+			want: []FlowEfficiency{
+				{Group: "Sprint 1", SprintID: 2, Issues: 1, ActiveTime: time.Hour, WaitingTime: 3 * time.Hour, BlockedTime: 3 * time.Hour, Efficiency: 0.25},
+				{Group: "Sprint 1", SprintID: 1, Issues: 2, ActiveTime: 8 * time.Hour, WaitingTime: 8 * time.Hour, BlockedTime: 4 * time.Hour, Efficiency: 0.5},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FlowEfficiencyBy(items, tt.by); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FlowEfficiencyBy() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,192 @@
+package report
+
+import (
+	"jira-integration/pkg/analytics"
+	"jira-integration/pkg/issue"
+	"sort"
+	"time"
+)
+
+const (
+	// minOutlierSamples is how many issues a point value needs before its
+	// cycle time spread is trusted to spot outliers.
+	minOutlierSamples = 4
+)
+
+type (
+	// EstimationSample is a done issue with its final estimate, cycle time
+	// and how many times it was estimated again after the first estimate.
+	EstimationSample struct {
+		IssueID               uint          `json:"issue_id"`
+		Key                   string        `json:"key"`
+		Project               string        `json:"project"`
+		IssueType             string        `json:"issue_type"`
+		Points                float64       `json:"points"`
+		CycleTime             time.Duration `json:"cycle_time"`
+		Reestimations         int           `json:"reestimations"`
+		ReestimatedAfterStart bool          `json:"reestimated_after_start"`
+	}
+
+	// PointStats is the cycle time spread of the issues estimated with the
+	// same points in a project and issue type.
+	PointStats struct {
+		Project   string                  `json:"project"`
+		IssueType string                  `json:"issue_type"`
+		Points    float64                 `json:"points"`
+		Issues    int                     `json:"issues"`
+		CycleTime analytics.DurationStats `json:"cycle_time"`
+		Min       time.Duration           `json:"min"`
+		Max       time.Duration           `json:"max"`
+		Outliers  int                     `json:"outliers"`
+	}
+
+	// Outlier is an issue whose cycle time is above the upper fence, third
+	// quartile plus 1.5 times the interquartile range, of its point value.
+	Outlier struct {
+		EstimationSample
+		Threshold time.Duration `json:"threshold"`
+	}
+
+	ReestimationStats struct {
+		Project     string  `json:"project"`
+		IssueType   string  `json:"issue_type"`
+		Issues      int     `json:"issues"`
+		Reestimated int     `json:"reestimated"`
+		AfterStart  int     `json:"after_start"`
+		Rate        float64 `json:"rate"`
+	}
+
+	EstimationReport struct {
+		Points       []PointStats        `json:"points"`
+		Outliers     []Outlier           `json:"outliers"`
+		Reestimation []ReestimationStats `json:"reestimation"`
+	}
+
+	pointKey struct {
+		project   string
+		issueType string
+		points    float64
+	}
+
+	typeKey struct {
+		project   string
+		issueType string
+	}
+)
+
+// NewEstimationSample pairs the issue estimate history with its metrics. It
+// returns false for issues not done or never estimated.
+func NewEstimationSample(i issue.Issue, metrics analytics.Metrics) (EstimationSample, bool) {
+	if metrics.DoneAt.IsZero() || metrics.CycleTime <= 0 || i.StoryPoints == nil {
+		return EstimationSample{}, false
+	}
+
+	sample := EstimationSample{
+		IssueID:   i.ID,
+		Key:       i.Key,
+		Project:   i.Project,
+		IssueType: i.IssueType,
+		Points:    float64(*i.StoryPoints),
+		CycleTime: metrics.CycleTime,
+	}
+
+	for _, c := range NewHistory(i).StoryPointsChanges() {
+		if c.From == "" || parsePoints(c.From) == parsePoints(c.To) {
+			continue
+		}
+
+		sample.Reestimations++
+		if !metrics.StartedAt.IsZero() && c.CreatedAt.After(metrics.StartedAt) {
+			sample.ReestimatedAfterStart = true
+		}
+	}
+
+	return sample, true
+}
+
+func NewEstimationReport(samples []EstimationSample) EstimationReport {
+	byPoints := map[pointKey][]EstimationSample{}
+	byType := map[typeKey]*ReestimationStats{}
+	for _, s := range samples {
+		k := pointKey{s.Project, s.IssueType, s.Points}
+		byPoints[k] = append(byPoints[k], s)
+
+		t := typeKey{s.Project, s.IssueType}
+		if byType[t] == nil {
+			byType[t] = &ReestimationStats{Project: s.Project, IssueType: s.IssueType}
+		}
+
+		byType[t].Issues++
+		if s.Reestimations > 0 {
+			byType[t].Reestimated++
+		}
+
+		if s.ReestimatedAfterStart {
+			byType[t].AfterStart++
+		}
+	}
+
+	var output EstimationReport
+	for k, group := range byPoints {
+		durations := make([]time.Duration, len(group), len(group))
+		for i, s := range group {
+			durations[i] = s.CycleTime
+		}
+
+		stats := PointStats{
+			Project:   k.project,
+			IssueType: k.issueType,
+			Points:    k.points,
+			Issues:    len(group),
+			CycleTime: analytics.NewDurationStats(durations),
+			Min:       analytics.Percentile(durations, 0),
+			Max:       analytics.Percentile(durations, 100),
+		}
+
+		if len(group) >= minOutlierSamples {
+			q1, q3 := analytics.Percentile(durations, 25), analytics.Percentile(durations, 75)
+			threshold := q3 + (q3-q1)*3/2
+			for _, s := range group {
+				if s.CycleTime > threshold {
+					stats.Outliers++
+					output.Outliers = append(output.Outliers, Outlier{EstimationSample: s, Threshold: threshold})
+				}
+			}
+		}
+
+		output.Points = append(output.Points, stats)
+	}
+
+	for _, stats := range byType {
+		stats.Rate = float64(stats.Reestimated) / float64(stats.Issues)
+		output.Reestimation = append(output.Reestimation, *stats)
+	}
+
+	sort.Slice(output.Points, func(i, j int) bool {
+		a, b := output.Points[i], output.Points[j]
+		if a.Project != b.Project {
+			return a.Project < b.Project
+		}
+
+		if a.IssueType != b.IssueType {
+			return a.IssueType < b.IssueType
+		}
+
+		return a.Points < b.Points
+	})
+
+	sort.Slice(output.Outliers, func(i, j int) bool {
+		return output.Outliers[i].CycleTime-output.Outliers[i].Threshold > output.Outliers[j].CycleTime-output.Outliers[j].Threshold
+	})
+
+	sort.Slice(output.Reestimation, func(i, j int) bool {
+		a, b := output.Reestimation[i], output.Reestimation[j]
+		if a.Project != b.Project {
+			return a.Project < b.Project
+		}
+
+		return a.IssueType < b.IssueType
+	})
+
+	return output
+}
@@ -0,0 +1,61 @@
+package report
+
+import (
+	"jira-integration/pkg/analytics"
+	"jira-integration/pkg/issue"
+	"testing"
+	"time"
+)
+
+func TestNewEstimationReport(t *testing.T) {
+	calculator := analytics.NewCalculator(analytics.DefaultConfig())
+	story := func(id uint, points uint, days int, changes ...issue.Changelog) issue.Issue {
+		return issue.Issue{
+			Stamp:          issue.Stamp{ID: id, CreatedAt: day(-30)},
+			Project:        "P",
+			IssueType:      "Story",
+			Status:         "Done",
+			StatusCategory: issue.StatusCategoryDone,
+			StoryPoints:    &points,
+			Changelog: append(changes,
+				issue.Changelog{From: "To Do", FromCategory: issue.StatusCategoryToDo, To: "In Progress", ToCategory: issue.StatusCategoryInProgress, CreatedAt: day(0)},
+				issue.Changelog{From: "In Progress", FromCategory: issue.StatusCategoryInProgress, To: "Done", ToCategory: issue.StatusCategoryDone, CreatedAt: day(days)},
+			),
+		}
+	}
+
+	issues := []issue.Issue{
+		story(1, 1, 1, pointsChange("", "1", day(-10))),
+		story(2, 1, 1, pointsChange("", "2", day(-10)), pointsChange("2", "1", day(-5))),
+		story(3, 1, 2),
+		story(4, 1, 2),
+		story(5, 1, 20, pointsChange("", "3", day(-10)), pointsChange("3", "1", day(5))),
+		story(6, 3, 5),
+	}
+
+	var samples []EstimationSample
+	for _, i := range issues {
+		if sample, ok := NewEstimationSample(i, calculator.Compute(i, day(30))); ok {
+			samples = append(samples, sample)
+		}
+	}
+
+	got := NewEstimationReport(samples)
+
+	if len(got.Points) != 2 || got.Points[0].Points != 1 || got.Points[0].Issues != 5 || got.Points[0].Outliers != 1 {
+		t.Errorf("Points = %+v", got.Points)
+	}
+
+	if got.Points[0].Max != 20*24*time.Hour {
+		t.Errorf("Max = %v, want %v", got.Points[0].Max, 20*24*time.Hour)
+	}
+
+	if len(got.Outliers) != 1 || got.Outliers[0].IssueID != 5 {
+		t.Errorf("Outliers = %+v", got.Outliers)
+	}
+
+	want := []ReestimationStats{{Project: "P", IssueType: "Story", Issues: 6, Reestimated: 2, AfterStart: 1, Rate: 2.0 / 6}}
+	if len(got.Reestimation) != 1 || got.Reestimation[0] != want[0] {
+		t.Errorf("Reestimation = %+v, want %+v", got.Reestimation, want)
+	}
+}
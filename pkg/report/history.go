@@ -0,0 +1,167 @@
+package report
+
+import (
+	"jira-integration/pkg/analytics"
+	"jira-integration/pkg/issue"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type (
+	// History answers what an issue looked like at a given moment by
+	// replaying its changelog backwards from the stored current state.
+	History struct {
+		issue       issue.Issue
+		statuses    []issue.Changelog
+		sprints     []issue.Changelog
+		storyPoints []issue.Changelog
+	}
+)
+
+func NewHistory(i issue.Issue) History {
+	h := History{issue: i}
+	for _, c := range i.Changelog {
+		switch {
+		case c.IsStatus():
+			h.statuses = append(h.statuses, c)
+		case c.Field == issue.FieldSprint:
+			h.sprints = append(h.sprints, c)
+		case c.Field == issue.FieldStoryPoints:
+			h.storyPoints = append(h.storyPoints, c)
+		}
+	}
+
+	for _, changes := range [][]issue.Changelog{h.statuses, h.sprints, h.storyPoints} {
+		sort.SliceStable(changes, func(a, b int) bool {
+			return changes[a].CreatedAt.Before(changes[b].CreatedAt)
+		})
+	}
+
+	return h
+}
+
+func (h History) Issue() issue.Issue {
+	return h.issue
+}
+
+func (h History) Exists(t time.Time) bool {
+	return !h.issue.CreatedAt.After(t)
+}
+
+// StatusAt returns the status name and category at t.
+func (h History) StatusAt(t time.Time) (string, string) {
+	change, found := valueAt(h.statuses, t)
+	if !found {
+		return h.issue.Status, h.issue.StatusCategory
+	}
+
+	if change.CreatedAt.After(t) {
+		return change.From, change.FromCategory
+	}
+
+	return change.To, change.ToCategory
+}
+
+func (h History) DoneAt(t time.Time, done analytics.StatusSet) bool {
+	return h.Exists(t) && done.Match(h.StatusAt(t))
+}
+
+// InSprintAt tells whether the issue belonged to the sprint at t. Without
+// sprint changes the current sprint is assumed since the issue creation.
+func (h History) InSprintAt(sprintID uint, t time.Time) bool {
+	if !h.Exists(t) {
+		return false
+	}
+
+	change, found := valueAt(h.sprints, t)
+	if !found {
+		return h.issue.Sprint != nil && h.issue.Sprint.ID == sprintID
+	}
+
+	if change.CreatedAt.After(t) {
+		return containsSprint(change.FromID, sprintID)
+	}
+
+	return containsSprint(change.ToID, sprintID)
+}
+
+// JoinedSprintBetween tells whether the issue was moved into the sprint
+// within (from, to].
+func (h History) JoinedSprintBetween(sprintID uint, from, to time.Time) bool {
+	for _, change := range h.sprints {
+		if change.CreatedAt.After(from) && !change.CreatedAt.After(to) &&
+			containsSprint(change.ToID, sprintID) && !containsSprint(change.FromID, sprintID) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// StoryPointsAt returns the estimate at t, zero when not estimated.
+func (h History) StoryPointsAt(t time.Time) float64 {
+	change, found := valueAt(h.storyPoints, t)
+	if !found {
+		if h.issue.StoryPoints == nil {
+			return 0
+		}
+
+		return float64(*h.issue.StoryPoints)
+	}
+
+	if change.CreatedAt.After(t) {
+		return parsePoints(change.From)
+	}
+
+	return parsePoints(change.To)
+}
+
+// StoryPointsChanges returns the estimate changes sorted by time.
+func (h History) StoryPointsChanges() []issue.Changelog {
+	return h.storyPoints
+}
+
+// StatusChanges returns the status transitions sorted by time.
+func (h History) StatusChanges() []issue.Changelog {
+	return h.statuses
+}
+
+// valueAt returns the last change up to t or, when t precedes every change,
+// the first one so its origin value can be used. found is false when the
+// field never changed.
+func valueAt(changes []issue.Changelog, t time.Time) (issue.Changelog, bool) {
+	if len(changes) == 0 {
+		return issue.Changelog{}, false
+	}
+
+	index := sort.Search(len(changes), func(i int) bool {
+		return changes[i].CreatedAt.After(t)
+	})
+
+	if index == 0 {
+		return changes[0], true
+	}
+
+	return changes[index-1], true
+}
+
+func containsSprint(ids string, sprintID uint) bool {
+	for _, id := range strings.Split(ids, ",") {
+		if parsed, err := strconv.ParseUint(strings.TrimSpace(id), 10, 64); err == nil && uint(parsed) == sprintID {
+			return true
+		}
+	}
+
+	return false
+}
+
+func parsePoints(value string) float64 {
+	parsed, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil {
+		return 0
+	}
+
+	return parsed
+}
@@ -0,0 +1,166 @@
+package report
+
+import (
+	"jira-integration/pkg/analytics"
+	"jira-integration/pkg/forecast"
+	"slices"
+	"sort"
+	"time"
+)
+
+const (
+	LevelTheme = "theme"
+	LevelEpic  = "epic"
+
+	rollupForecastPercentile = 85
+	rollupForecastTrials     = 1000
+	rollupForecastWeeks      = 12
+)
+
+type (
+	// Rollup is the progress of an epic or theme measured by the work items
+	// under it. Themes count the items under their epics.
+	Rollup struct {
+		Key          string         `json:"key"`
+		Summary      string         `json:"summary"`
+		Level        string         `json:"level"`
+		Status       string         `json:"status"`
+		ParentKey    string         `json:"parent_key,omitempty"`
+		Epics        int            `json:"epics,omitempty"`
+		Children     int            `json:"children"`
+		Done         int            `json:"done"`
+		Points       float64        `json:"points"`
+		DonePoints   float64        `json:"done_points"`
+		Statuses     map[string]int `json:"statuses"`
+		StartedAt    time.Time      `json:"started_at,omitempty"`
+		LastDoneAt   time.Time      `json:"last_done_at,omitempty"`
+		ForecastedAt time.Time      `json:"forecasted_at,omitempty"`
+	}
+
+	// RollupBuilder tells epics and themes apart from the work items by
+	// their issue types.
+	RollupBuilder struct {
+		epicTypes  []string
+		themeTypes []string
+	}
+)
+
+func NewRollupBuilder(epicTypes, themeTypes []string) *RollupBuilder {
+	return &RollupBuilder{
+		epicTypes:  epicTypes,
+		themeTypes: themeTypes,
+	}
+}
+
+// Build rolls the work items up to their epics and themes. The completion is
+// forecasted at the 85th percentile of a Monte Carlo simulation of the
+// weekly throughput of the rollup itself in the last weeks.
+func (b RollupBuilder) Build(items []analytics.WorkItem, now time.Time) []Rollup {
+	byKey := map[string]analytics.WorkItem{}
+	for _, item := range items {
+		byKey[item.Key] = item
+	}
+
+	rollups := map[string]*Rollup{}
+	get := func(key, level string) *Rollup {
+		if r, exists := rollups[key]; exists {
+			return r
+		}
+
+		parent := byKey[key]
+		r := &Rollup{Key: key, Summary: parent.Summary, Level: level, Status: parent.Status, ParentKey: parent.ParentKey, Statuses: map[string]int{}}
+		rollups[key] = r
+		return r
+	}
+
+	doneDates := map[string][]time.Time{}
+	for _, item := range items {
+		switch {
+		case slices.Contains(b.themeTypes, item.IssueType):
+			get(item.Key, LevelTheme)
+		case slices.Contains(b.epicTypes, item.IssueType):
+			get(item.Key, LevelEpic)
+			if item.ParentKey != "" {
+				get(item.ParentKey, LevelTheme).Epics++
+			}
+		case item.ParentKey != "":
+			parent := byKey[item.ParentKey]
+			level := LevelEpic
+			if slices.Contains(b.themeTypes, parent.IssueType) {
+				level = LevelTheme
+			}
+
+			targets := []*Rollup{get(item.ParentKey, level)}
+			if level == LevelEpic && parent.ParentKey != "" {
+				targets = append(targets, get(parent.ParentKey, LevelTheme))
+			}
+
+			for _, r := range targets {
+				r.add(item)
+				if !item.DoneAt.IsZero() {
+					doneDates[r.Key] = append(doneDates[r.Key], item.DoneAt)
+				}
+			}
+		}
+	}
+
+	output := make([]Rollup, 0, len(rollups))
+	for _, r := range rollups {
+		r.ForecastedAt = forecastRollup(*r, doneDates[r.Key], now)
+		output = append(output, *r)
+	}
+
+	sort.Slice(output, func(i, j int) bool {
+		if output[i].Level != output[j].Level {
+			return output[i].Level == LevelTheme
+		}
+
+		return output[i].Key < output[j].Key
+	})
+
+	return output
+}
+
+func (r *Rollup) add(item analytics.WorkItem) {
+	var points float64
+	if item.StoryPoints != nil {
+		points = float64(*item.StoryPoints)
+	}
+
+	r.Children++
+	r.Points += points
+	r.Statuses[item.Status]++
+	if !item.StartedAt.IsZero() && (r.StartedAt.IsZero() || item.StartedAt.Before(r.StartedAt)) {
+		r.StartedAt = item.StartedAt
+	}
+
+	if item.DoneAt.IsZero() {
+		return
+	}
+
+	r.Done++
+	r.DonePoints += points
+	if item.DoneAt.After(r.LastDoneAt) {
+		r.LastDoneAt = item.DoneAt
+	}
+}
+
+func forecastRollup(r Rollup, done []time.Time, now time.Time) time.Time {
+	remaining := r.Children - r.Done
+	switch {
+	case r.Children == 0:
+		return time.Time{}
+	case remaining == 0:
+		return r.LastDoneAt
+	}
+
+	from := now.AddDate(0, 0, -7*rollupForecastWeeks)
+	simulator, err := forecast.NewSimulator(forecast.WeeklyThroughput(done, from, rollupForecastWeeks), rollupForecastTrials, 1)
+	if err != nil {
+		// without throughput there's no pace to forecast from
+		return time.Time{}
+	}
+
+	outcomes := simulator.When(now, remaining, []int{rollupForecastPercentile}).Outcomes
+	return outcomes[0].Date
+}
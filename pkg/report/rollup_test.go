@@ -0,0 +1,49 @@
+package report
+
+import (
+	"jira-integration/pkg/analytics"
+	"reflect"
+	"testing"
+)
+
+func TestRollupBuilder_Build(t *testing.T) {
+	items := []analytics.WorkItem{
+		{Key: "T-1", IssueType: "Theme", Summary: "Theme"},
+		{Key: "E-1", IssueType: "Epic", Summary: "Epic", ParentKey: "T-1"},
+		{Key: "E-2", IssueType: "Epic", Summary: "Empty epic", ParentKey: "T-1"},
+		{Key: "S-1", IssueType: "Story", ParentKey: "E-1", Status: "Done", StoryPoints: points(3), StartedAt: day(-10), DoneAt: day(-8)},
+		{Key: "S-2", IssueType: "Story", ParentKey: "E-1", Status: "Done", StoryPoints: points(5), StartedAt: day(-12), DoneAt: day(-2)},
+		{Key: "S-3", IssueType: "Story", ParentKey: "E-1", Status: "In Progress", StoryPoints: points(2), StartedAt: day(-1)},
+		{Key: "S-4", IssueType: "Task", ParentKey: "T-1", Status: "To Do"},
+	}
+
+	got := NewRollupBuilder([]string{"Epic"}, []string{"Theme"}).Build(items, day(0))
+
+	want := []Rollup{
+		{
+			Key: "T-1", Summary: "Theme", Level: LevelTheme, Epics: 2, Children: 4, Done: 2, Points: 10, DonePoints: 8,
+			Statuses: map[string]int{"Done": 2, "In Progress": 1, "To Do": 1}, StartedAt: day(-12), LastDoneAt: day(-2),
+		},
+		{
+			Key: "E-1", Summary: "Epic", Level: LevelEpic, ParentKey: "T-1", Children: 3, Done: 2, Points: 10, DonePoints: 8,
+			Statuses: map[string]int{"Done": 2, "In Progress": 1}, StartedAt: day(-12), LastDoneAt: day(-2),
+		},
+		{Key: "E-2", Summary: "Empty epic", Level: LevelEpic, ParentKey: "T-1", Statuses: map[string]int{}},
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("Build() = %+v, want %+v", got, want)
+	}
+
+	for i := range got {
+		if got[i].Children > got[i].Done && got[i].ForecastedAt.Before(day(0)) {
+			t.Errorf("%s forecasted at %v, before now", got[i].Key, got[i].ForecastedAt)
+		}
+
+		got[i].ForecastedAt = want[i].ForecastedAt
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Build() = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,151 @@
+package report
+
+import (
+	"jira-integration/pkg/analytics"
+	"jira-integration/pkg/issue"
+	"sort"
+	"time"
+)
+
+type (
+	Scope struct {
+		Issues int     `json:"issues"`
+		Points float64 `json:"points"`
+	}
+
+	SprintItem struct {
+		IssueID     uint    `json:"issue_id"`
+		Key         string  `json:"key"`
+		Points      float64 `json:"points"`
+		Committed   bool    `json:"committed"`
+		Added       bool    `json:"added"`
+		Removed     bool    `json:"removed"`
+		Completed   bool    `json:"completed"`
+		CarriedOver bool    `json:"carried_over"`
+	}
+
+	SprintReport struct {
+		Sprint          issue.Sprint `json:"sprint"`
+		Committed       Scope        `json:"committed"`
+		Added           Scope        `json:"added"`
+		Removed         Scope        `json:"removed"`
+		Completed       Scope        `json:"completed"`
+		CarryOver       Scope        `json:"carry_over"`
+		CompletionRatio float64      `json:"completion_ratio"`
+		Velocity        float64      `json:"velocity"`
+		RollingVelocity float64      `json:"rolling_velocity"`
+		Items           []SprintItem `json:"items,omitempty"`
+	}
+
+	SprintReporter struct {
+		done analytics.StatusSet
+	}
+)
+
+func NewSprintReporter(done analytics.StatusSet) *SprintReporter {
+	return &SprintReporter{
+		done: done,
+	}
+}
+
+// SprintEnd is when the sprint scope is evaluated: its completion, its
+// planned end or now for sprints still running.
+func SprintEnd(s issue.Sprint, now time.Time) time.Time {
+	switch {
+	case !s.CompletedAt.IsZero():
+		return s.CompletedAt
+	case !s.EndedAt.IsZero() && s.EndedAt.Before(now):
+		return s.EndedAt
+	default:
+		return now
+	}
+}
+
+// Build reconstructs the sprint at its start from the changelog of every
+// issue that was part of it at some point, then compares it with its end.
+func (r SprintReporter) Build(s issue.Sprint, issues []issue.Issue, now time.Time) SprintReport {
+	output := SprintReport{Sprint: s}
+	start, end := s.StartedAt, SprintEnd(s, now)
+
+	for _, i := range issues {
+		h := NewHistory(i)
+		committed := h.InSprintAt(s.ID, start)
+		added := !committed && (h.JoinedSprintBetween(s.ID, start, end) || (h.issue.CreatedAt.After(start) && h.InSprintAt(s.ID, end)))
+		if !committed && !added {
+			continue
+		}
+
+		inSprintAtEnd := h.InSprintAt(s.ID, end)
+		doneAtEnd := h.DoneAt(end, r.done)
+		item := SprintItem{
+			IssueID:     i.ID,
+			Key:         i.Key,
+			Points:      h.StoryPointsAt(end),
+			Committed:   committed,
+			Added:       added,
+			Removed:     !inSprintAtEnd,
+			Completed:   inSprintAtEnd && doneAtEnd,
+			CarriedOver: inSprintAtEnd && !doneAtEnd,
+		}
+
+		if committed {
+			output.Committed.add(h.StoryPointsAt(start))
+		}
+
+		if item.Added {
+			output.Added.add(item.Points)
+		}
+
+		if item.Removed {
+			output.Removed.add(item.Points)
+		}
+
+		if item.Completed {
+			output.Completed.add(item.Points)
+		}
+
+		if item.CarriedOver {
+			output.CarryOver.add(item.Points)
+		}
+
+		output.Items = append(output.Items, item)
+	}
+
+	output.Velocity = output.Completed.Points
+	output.RollingVelocity = output.Velocity
+	if output.Committed.Points != 0 {
+		output.CompletionRatio = output.Completed.Points / output.Committed.Points
+	}
+
+	sort.Slice(output.Items, func(a, b int) bool {
+		return output.Items[a].IssueID < output.Items[b].IssueID
+	})
+
+	return output
+}
+
+// RollVelocity sorts the reports by sprint start and sets the average
+// velocity of each sprint and up to window-1 sprints before it.
+func RollVelocity(reports []SprintReport, window int) []SprintReport {
+	sort.SliceStable(reports, func(a, b int) bool {
+		return reports[a].Sprint.StartedAt.Before(reports[b].Sprint.StartedAt)
+	})
+
+	window = max(window, 1)
+	for i := range reports {
+		from := max(0, i-window+1)
+		var total float64
+		for _, previous := range reports[from : i+1] {
+			total += previous.Velocity
+		}
+
+		reports[i].RollingVelocity = total / float64(i-from+1)
+	}
+
+	return reports
+}
+
+func (s *Scope) add(points float64) {
+	s.Issues++
+	s.Points += points
+}
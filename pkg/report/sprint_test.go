@@ -0,0 +1,123 @@
+package report
+
+import (
+	"jira-integration/pkg/analytics"
+	"jira-integration/pkg/issue"
+	"reflect"
+	"testing"
+	"time"
+)
+
+var (
+	day0 = time.Date(2024, 3, 11, 9, 0, 0, 0, time.UTC)
+)
+
+func day(n int) time.Time {
+	return day0.AddDate(0, 0, n)
+}
+
+func points(value uint) *uint {
+	return &value
+}
+
+func statusChange(from, to, toCategory string, at time.Time) issue.Changelog {
+	return issue.Changelog{Field: issue.FieldStatus, From: from, To: to, ToCategory: toCategory, CreatedAt: at}
+}
+
+func sprintChange(fromID, toID string, at time.Time) issue.Changelog {
+	return issue.Changelog{Field: issue.FieldSprint, FromID: fromID, ToID: toID, CreatedAt: at}
+}
+
+func pointsChange(from, to string, at time.Time) issue.Changelog {
+	return issue.Changelog{Field: issue.FieldStoryPoints, From: from, To: to, CreatedAt: at}
+}
+
+func TestSprintReporter_Build(t *testing.T) {
+	sprint := issue.Sprint{ID: 10, StartedAt: day(0), EndedAt: day(14), CompletedAt: day(14)}
+	inSprint := &issue.Sprint{ID: 10}
+	issues := []issue.Issue{
+		{
+			Stamp:          issue.Stamp{ID: 1, Key: "A", CreatedAt: day(-5)},
+			Status:         "Done",
+			StatusCategory: issue.StatusCategoryDone,
+			Sprint:         inSprint,
+			StoryPoints:    points(3),
+			Changelog: []issue.Changelog{
+				pointsChange("1", "3", day(2)),
+				statusChange("To Do", "Done", issue.StatusCategoryDone, day(5)),
+			},
+		},
+		{
+			Stamp:          issue.Stamp{ID: 2, Key: "B", CreatedAt: day(-5)},
+			Status:         "In Progress",
+			StatusCategory: issue.StatusCategoryInProgress,
+			Sprint:         &issue.Sprint{ID: 11},
+			StoryPoints:    points(5),
+			Changelog: []issue.Changelog{
+				sprintChange("10", "10, 11", day(14).Add(time.Minute)),
+			},
+		},
+		{
+			Stamp:          issue.Stamp{ID: 3, Key: "C", CreatedAt: day(-5)},
+			Status:         "Done",
+			StatusCategory: issue.StatusCategoryDone,
+			Sprint:         inSprint,
+			StoryPoints:    points(2),
+			Changelog: []issue.Changelog{
+				sprintChange("", "10", day(3)),
+				statusChange("To Do", "Done", issue.StatusCategoryDone, day(10)),
+			},
+		},
+		{
+			Stamp:       issue.Stamp{ID: 4, Key: "D", CreatedAt: day(-5)},
+			StoryPoints: points(8),
+			Changelog: []issue.Changelog{
+				sprintChange("10", "", day(4)),
+			},
+		},
+		{
+			Stamp:       issue.Stamp{ID: 5, Key: "E", CreatedAt: day(-5)},
+			Sprint:      &issue.Sprint{ID: 9},
+			StoryPoints: points(13),
+		},
+	}
+
+	got := NewSprintReporter(analytics.DefaultConfig().Done).Build(sprint, issues, day(20))
+	want := SprintReport{
+		Sprint:          sprint,
+		Committed:       Scope{Issues: 3, Points: 14},
+		Added:           Scope{Issues: 1, Points: 2},
+		Removed:         Scope{Issues: 1, Points: 8},
+		Completed:       Scope{Issues: 2, Points: 5},
+		CarryOver:       Scope{Issues: 1, Points: 5},
+		CompletionRatio: 5.0 / 14.0,
+		Velocity:        5,
+		RollingVelocity: 5,
+		Items: []SprintItem{
+			{IssueID: 1, Key: "A", Points: 3, Committed: true, Completed: true},
+			{IssueID: 2, Key: "B", Points: 5, Committed: true, CarriedOver: true},
+			{IssueID: 3, Key: "C", Points: 2, Added: true, Completed: true},
+			{IssueID: 4, Key: "D", Points: 8, Committed: true, Removed: true},
+		},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Build() = %+v, want %+v", got, want)
+	}
+}
+
+func TestRollVelocity(t *testing.T) {
+	reports := []SprintReport{
+		{Sprint: issue.Sprint{ID: 3, StartedAt: day(28)}, Velocity: 30},
+		{Sprint: issue.Sprint{ID: 1, StartedAt: day(0)}, Velocity: 10},
+		{Sprint: issue.Sprint{ID: 2, StartedAt: day(14)}, Velocity: 20},
+	}
+
+	got := RollVelocity(reports, 2)
+	want := []float64{10, 15, 25}
+	for i, report := range got {
+		if report.RollingVelocity != want[i] {
+			t.Errorf("RollVelocity()[%d] = %v, want %v", i, report.RollingVelocity, want[i])
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"jira-integration/pkg/analytics"
+	"jira-integration/pkg/report"
+	"time"
+)
+
+type (
+	WorkItemDatabase interface {
+		ListInProgress(ctx context.Context) ([]analytics.WorkItem, error)
+		ListDone(ctx context.Context, from, to time.Time) ([]analytics.WorkItem, error)
+	}
+
+	AgingUseCase struct {
+		db  WorkItemDatabase
+		now func() time.Time
+	}
+)
+
+func NewAgingUseCase(db WorkItemDatabase) *AgingUseCase {
+	return &AgingUseCase{
+		db:  db,
+		now: time.Now,
+	}
+}
+
+// Execute compares the work in progress with the cycle time of the issues
+// done in the last weeks.
+func (uc AgingUseCase) Execute(ctx context.Context, weeks int) ([]report.AgingItem, error) {
+	now := uc.now()
+	inProgress, err := uc.db.ListInProgress(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("while listing issues in progress: %w", err)
+	}
+
+	done, err := uc.db.ListDone(ctx, now.AddDate(0, 0, -7*weeks), now)
+	if err != nil {
+		return nil, fmt.Errorf("while listing done issues: %w", err)
+	}
+
+	return report.Aging(inProgress, done, now), nil
+}
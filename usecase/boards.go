@@ -0,0 +1,164 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"jira-integration/pkg/issue"
+	"jira-integration/pkg/logging"
+)
+
+type (
+	BoardClient interface {
+		GetBoard(ctx context.Context, boardID uint) (issue.Board, error)
+		GetBoards(ctx context.Context, projectKeyOrID string, startAt int) ([]issue.Board, bool, error)
+		GetBoardSprints(ctx context.Context, boardID uint, states []string, startAt int) ([]issue.Sprint, bool, error)
+	}
+
+	BoardDatabase interface {
+		SaveBoard(ctx context.Context, b issue.Board) error
+		SaveSprint(ctx context.Context, s issue.Sprint) error
+	}
+
+	BoardConfigurationClient interface {
+		BoardClient
+		GetBoardColumns(ctx context.Context, boardID uint) ([]issue.BoardColumn, error)
+	}
+
+	BoardConfigurationDatabase interface {
+		BoardDatabase
+		SaveBoardColumns(ctx context.Context, boardID uint, columns []issue.BoardColumn) error
+	}
+
+	SyncBoardsUseCase struct {
+		db     BoardConfigurationDatabase
+		client BoardConfigurationClient
+	}
+
+	DiscoverSprintsUseCase struct {
+		db     BoardDatabase
+		client BoardClient
+	}
+)
+
+func NewSyncBoardsUseCase(client BoardConfigurationClient, db BoardConfigurationDatabase) *SyncBoardsUseCase {
+	return &SyncBoardsUseCase{
+		client: client,
+		db:     db,
+	}
+}
+
+// Execute syncs the given boards, or every board of the project when no
+// board ID is given, together with the status to column mapping of each one.
+func (uc SyncBoardsUseCase) Execute(ctx context.Context, projectKeyOrID string, boardIDs []uint) error {
+	if len(boardIDs) != 0 {
+		for _, boardID := range boardIDs {
+			b, err := uc.client.GetBoard(ctx, boardID)
+			if err != nil {
+				return fmt.Errorf("while fetching board %d: %w", boardID, err)
+			}
+
+			if err := uc.syncBoard(ctx, b); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	startAt := 0
+	for {
+		boards, isLast, err := uc.client.GetBoards(ctx, projectKeyOrID, startAt)
+		if err != nil {
+			return fmt.Errorf("while listing boards: %w", err)
+		}
+
+		for _, b := range boards {
+			if err := uc.syncBoard(ctx, b); err != nil {
+				return err
+			}
+		}
+
+		if isLast {
+			return nil
+		}
+
+		startAt += len(boards)
+	}
+}
+
+func (uc SyncBoardsUseCase) syncBoard(ctx context.Context, b issue.Board) error {
+	logging.FromContext(ctx).Info("syncing board", "board_id", b.ID, "board", b.Name)
+	columns, err := uc.client.GetBoardColumns(ctx, b.ID)
+	if err != nil {
+		return fmt.Errorf("while fetching configuration of board %d: %w", b.ID, err)
+	}
+
+	if err := uc.db.SaveBoard(ctx, b); err != nil {
+		return fmt.Errorf("while saving board %d: %w", b.ID, err)
+	}
+
+	if err := uc.db.SaveBoardColumns(ctx, b.ID, columns); err != nil {
+		return fmt.Errorf("while saving columns of board %d: %w", b.ID, err)
+	}
+
+	return nil
+}
+
+func NewDiscoverSprintsUseCase(client BoardClient, db BoardDatabase) *DiscoverSprintsUseCase {
+	return &DiscoverSprintsUseCase{
+		client: client,
+		db:     db,
+	}
+}
+
+// Execute upserts every sprint of the given boards, including the ones that
+// no fetched issue points to yet. The states filter is optional.
+func (uc DiscoverSprintsUseCase) Execute(ctx context.Context, boardIDs []uint, states []string) error {
+	for _, boardID := range boardIDs {
+		b, err := uc.client.GetBoard(ctx, boardID)
+		if err != nil {
+			return fmt.Errorf("while fetching board %d: %w", boardID, err)
+		}
+
+		if err := uc.db.SaveBoard(ctx, b); err != nil {
+			return fmt.Errorf("while saving board %d: %w", boardID, err)
+		}
+
+		if err := uc.syncSprints(ctx, b, states); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (uc DiscoverSprintsUseCase) syncSprints(ctx context.Context, b issue.Board, states []string) error {
+	startAt := 0
+	for {
+		sprints, isLast, err := uc.client.GetBoardSprints(ctx, b.ID, states, startAt)
+		if err != nil {
+			return fmt.Errorf("while listing sprints of board %d: %w", b.ID, err)
+		}
+
+		for _, s := range sprints {
+			if s.BoardID == 0 {
+				s.BoardID = b.ID
+			}
+
+			if s.BoardID == b.ID {
+				s.BoardName = b.Name
+			}
+
+			logging.FromContext(ctx).Info("syncing sprint", "sprint_id", s.ID, "sprint", s.Name, "board_id", b.ID)
+			if err := uc.db.SaveSprint(ctx, s); err != nil {
+				return fmt.Errorf("while saving sprint %d: %w", s.ID, err)
+			}
+		}
+
+		if isLast {
+			return nil
+		}
+
+		startAt += len(sprints)
+	}
+}
@@ -0,0 +1,161 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"jira-integration/pkg/issue"
+	"reflect"
+	"testing"
+)
+
+type (
+	stubBoardClient struct {
+		boards  map[uint]issue.Board
+		pages   [][]issue.Board
+		columns map[uint][]issue.BoardColumn
+		sprints map[uint][]issue.Sprint
+		err     error
+	}
+
+	memoryBoardDatabase struct {
+		boards  []issue.Board
+		columns map[uint][]issue.BoardColumn
+		sprints []issue.Sprint
+	}
+)
+
+func (s stubBoardClient) GetBoard(_ context.Context, boardID uint) (issue.Board, error) {
+	return s.boards[boardID], nil
+}
+
+func (s stubBoardClient) GetBoards(_ context.Context, _ string, startAt int) ([]issue.Board, bool, error) {
+	seen := 0
+	for i, page := range s.pages {
+		if seen == startAt {
+			return page, i == len(s.pages)-1, nil
+		}
+
+		seen += len(page)
+	}
+
+	return nil, true, nil
+}
+
+func (s stubBoardClient) GetBoardSprints(_ context.Context, boardID uint, _ []string, startAt int) ([]issue.Sprint, bool, error) {
+	return s.sprints[boardID][startAt:], true, nil
+}
+
+func (s stubBoardClient) GetBoardColumns(_ context.Context, boardID uint) ([]issue.BoardColumn, error) {
+	return s.columns[boardID], s.err
+}
+
+func (m *memoryBoardDatabase) SaveBoard(_ context.Context, b issue.Board) error {
+	m.boards = append(m.boards, b)
+	return nil
+}
+
+func (m *memoryBoardDatabase) SaveSprint(_ context.Context, s issue.Sprint) error {
+	m.sprints = append(m.sprints, s)
+	return nil
+}
+
+func (m *memoryBoardDatabase) SaveBoardColumns(_ context.Context, boardID uint, columns []issue.BoardColumn) error {
+	m.columns[boardID] = columns
+	return nil
+}
+
+func TestSyncBoardsUseCase_Execute(t *testing.T) {
+	scrum := issue.Board{ID: 1, Name: "Payments"}
+	kanban := issue.Board{ID: 2, Name: "Support"}
+	columns := map[uint][]issue.BoardColumn{
+		1: {
+			{Name: "To Do", Position: 0, StatusIDs: []string{"1"}},
+			{Name: "In Progress", Position: 1, StatusIDs: []string{"3", "10001"}},
+			{Name: "Done", Position: 2, StatusIDs: []string{"10002"}},
+		},
+		2: {
+			{Name: "Backlog", Position: 0, StatusIDs: []string{"1"}},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		client      stubBoardClient
+		boardIDs    []uint
+		wantBoards  []issue.Board
+		wantColumns map[uint][]issue.BoardColumn
+		wantErr     bool
+	}{
+		{
+			name: "sync only the given boards",
+			client: stubBoardClient{
+				boards:  map[uint]issue.Board{1: scrum, 2: kanban},
+				columns: columns,
+			},
+			boardIDs:    []uint{2},
+			wantBoards:  []issue.Board{kanban},
+			wantColumns: map[uint][]issue.BoardColumn{2: columns[2]},
+		},
+		{
+			name: "sync every page of the project boards with their columns",
+			client: stubBoardClient{
+				pages:   [][]issue.Board{{scrum}, {kanban}},
+				columns: columns,
+			},
+			wantBoards:  []issue.Board{scrum, kanban},
+			wantColumns: columns,
+		},
+		{
+			name: "save nothing when the configuration can't be fetched",
+			client: stubBoardClient{
+				boards: map[uint]issue.Board{1: scrum},
+				err:    errors.New("forbidden"),
+			},
+			boardIDs:    []uint{1},
+			wantColumns: map[uint][]issue.BoardColumn{},
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := &memoryBoardDatabase{columns: map[uint][]issue.BoardColumn{}}
+			err := NewSyncBoardsUseCase(tt.client, db).Execute(context.Background(), "PAY", tt.boardIDs)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Execute() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(db.boards, tt.wantBoards) {
+				t.Errorf("Execute() saved boards %v, want %v", db.boards, tt.wantBoards)
+			}
+			if !reflect.DeepEqual(db.columns, tt.wantColumns) {
+				t.Errorf("Execute() saved columns %v, want %v", db.columns, tt.wantColumns)
+			}
+		})
+	}
+}
+
+func TestDiscoverSprintsUseCase_Execute(t *testing.T) {
+	client := stubBoardClient{
+		boards: map[uint]issue.Board{1: {ID: 1, Name: "Payments"}},
+		sprints: map[uint][]issue.Sprint{
+			1: {
+				{ID: 10, Name: "PAY 1"},
+				{ID: 11, Name: "PAY 2", BoardID: 1},
+				{ID: 12, Name: "Shared", BoardID: 7},
+			},
+		},
+	}
+
+	db := &memoryBoardDatabase{}
+	if err := NewDiscoverSprintsUseCase(client, db).Execute(context.Background(), []uint{1}, nil); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	want := []issue.Sprint{
+		{ID: 10, Name: "PAY 1", BoardID: 1, BoardName: "Payments"},
+		{ID: 11, Name: "PAY 2", BoardID: 1, BoardName: "Payments"},
+		{ID: 12, Name: "Shared", BoardID: 7},
+	}
+	if !reflect.DeepEqual(db.sprints, want) {
+		t.Errorf("Execute() saved sprints %v, want %v", db.sprints, want)
+	}
+}
@@ -0,0 +1,74 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"jira-integration/pkg/issue"
+	"jira-integration/pkg/report"
+	"time"
+)
+
+type (
+	BurndownDatabase interface {
+		GetSprint(ctx context.Context, sprintID uint) (issue.Sprint, bool, error)
+		GetSprintsByState(ctx context.Context, states []string) ([]issue.Sprint, error)
+		ListSprintIssues(ctx context.Context, sprintID uint) ([]issue.Issue, error)
+	}
+
+	BurndownUseCase struct {
+		db      BurndownDatabase
+		builder *report.BurndownBuilder
+		now     func() time.Time
+	}
+)
+
+func NewBurndownUseCase(builder *report.BurndownBuilder, db BurndownDatabase) *BurndownUseCase {
+	return &BurndownUseCase{
+		db:      db,
+		builder: builder,
+		now:     time.Now,
+	}
+}
+
+// Execute builds the series of the given sprints or, when none is given,
+// of every active sprint.
+func (uc BurndownUseCase) Execute(ctx context.Context, sprintIDs ...uint) ([]report.Burndown, error) {
+	sprints, err := uc.sprints(ctx, sprintIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	output := make([]report.Burndown, len(sprints), len(sprints))
+	for i, s := range sprints {
+		issues, err := uc.db.ListSprintIssues(ctx, s.ID)
+		if err != nil {
+			return nil, fmt.Errorf("while listing issues of sprint %d: %w", s.ID, err)
+		}
+
+		output[i] = uc.builder.Build(s, issues, uc.now())
+	}
+
+	return output, nil
+}
+
+func (uc BurndownUseCase) sprints(ctx context.Context, sprintIDs []uint) ([]issue.Sprint, error) {
+	if len(sprintIDs) == 0 {
+		return uc.db.GetSprintsByState(ctx, []string{"active"})
+	}
+
+	output := make([]issue.Sprint, len(sprintIDs), len(sprintIDs))
+	for i, id := range sprintIDs {
+		s, exists, err := uc.db.GetSprint(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+
+		if !exists {
+			return nil, fmt.Errorf("%w: %d", SprintNotFoundErr, id)
+		}
+
+		output[i] = s
+	}
+
+	return output, nil
+}
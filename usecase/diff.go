@@ -0,0 +1,225 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"jira-integration/pkg/issue"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const (
+	StatusField      = "status"
+	SprintField      = "sprint"
+	StoryPointsField = "story_points"
+	LabelsField      = "labels"
+	ParentField      = "parent"
+	ChangelogField   = "changelog"
+)
+
+type (
+	StoredIssueDatabase interface {
+		StatusCatalog
+		GetIssueByKey(ctx context.Context, key string) (issue.Issue, bool, error)
+	}
+
+	FieldChange struct {
+		Field   string
+		Stored  string
+		Fetched string
+	}
+
+	// IssueDiff tells what writing the fetched issue would change. New issues
+	// aren't stored yet, so they have no changes.
+	IssueDiff struct {
+		ID      uint
+		Key     string
+		New     bool
+		Changes []FieldChange
+	}
+
+	IssueDiffHandler func(ctx context.Context, diff IssueDiff) error
+
+	// DiffSummary counts the compared issues by what writing them would do.
+	DiffSummary struct {
+		Compared int
+		New      int
+		Changed  int
+	}
+
+	// DiffDatabase stands for the database in a dry run of FetchUseCase:
+	// instead of writing the issues, it compares them with the stored ones.
+	// It reports no issue as stored, so none is skipped by StreamUseCase for
+	// being up to date, since mapping changes affect those as well.
+	DiffDatabase struct {
+		db      StoredIssueDatabase
+		handler IssueDiffHandler
+	}
+)
+
+func NewDiffDatabase(db StoredIssueDatabase, handler IssueDiffHandler) *DiffDatabase {
+	return &DiffDatabase{
+		db:      db,
+		handler: handler,
+	}
+}
+
+func (d DiffDatabase) GetByID(context.Context, uint) (issue.Stamp, bool, error) {
+	return issue.Stamp{}, false, nil
+}
+
+func (d DiffDatabase) GetStatuses(ctx context.Context) (issue.Statuses, error) {
+	return d.db.GetStatuses(ctx)
+}
+
+func (d DiffDatabase) CreateIssue(ctx context.Context, i issue.Issue) error {
+	return d.compare(ctx, i)
+}
+
+func (d DiffDatabase) UpdateIssue(ctx context.Context, i issue.Issue) error {
+	return d.compare(ctx, i)
+}
+
+func (d DiffDatabase) compare(ctx context.Context, fetched issue.Issue) error {
+	stored, exists, err := d.db.GetIssueByKey(ctx, fetched.Key)
+	if err != nil {
+		return fmt.Errorf("while loading stored issue %s: %w", fetched.Key, err)
+	}
+
+	diff := IssueDiff{
+		ID:  fetched.ID,
+		Key: fetched.Key,
+		New: !exists,
+	}
+
+	if exists {
+		diff.Changes = DiffIssues(stored, fetched)
+	}
+
+	return d.handler(ctx, diff)
+}
+
+// Changed tells whether writing the issue would change any row.
+func (d IssueDiff) Changed() bool {
+	return d.New || len(d.Changes) != 0
+}
+
+// Add counts the diff of one more issue.
+func (s *DiffSummary) Add(diff IssueDiff) {
+	s.Compared++
+	switch {
+	case diff.New:
+		s.New++
+	case diff.Changed():
+		s.Changed++
+	}
+}
+
+func (s DiffSummary) Unchanged() int {
+	return s.Compared - s.New - s.Changed
+}
+
+// DiffIssues compares the fields mappings are most likely to affect. Each
+// changelog entry that would be added, removed or rewritten is a change.
+func DiffIssues(stored, fetched issue.Issue) []FieldChange {
+	fields := []FieldChange{
+		{Field: StatusField, Stored: stored.Status, Fetched: fetched.Status},
+		{Field: SprintField, Stored: sprintName(stored.Sprint), Fetched: sprintName(fetched.Sprint)},
+		{Field: StoryPointsField, Stored: storyPoints(stored.StoryPoints), Fetched: storyPoints(fetched.StoryPoints)},
+		{Field: LabelsField, Stored: labels(stored.Labels), Fetched: labels(fetched.Labels)},
+		{Field: ParentField, Stored: parentKey(stored.Parent), Fetched: parentKey(fetched.Parent)},
+	}
+
+	var output []FieldChange
+	for _, field := range fields {
+		if field.Stored != field.Fetched {
+			output = append(output, field)
+		}
+	}
+
+	return append(output, diffChangelog(stored.Changelog, fetched.Changelog)...)
+}
+
+// diffChangelog matches the entries by history id and field, as they are
+// stored.
+func diffChangelog(stored, fetched []issue.Changelog) []FieldChange {
+	type key struct {
+		id    uint
+		field string
+	}
+
+	keyOf := func(c issue.Changelog) key {
+		if c.IsStatus() {
+			return key{id: c.ID, field: issue.FieldStatus}
+		}
+
+		return key{id: c.ID, field: c.Field}
+	}
+
+	storedEntries := make(map[key]string, len(stored))
+	for _, c := range stored {
+		storedEntries[keyOf(c)] = changelogEntry(c)
+	}
+
+	var output []FieldChange
+	fetchedKeys := make(map[key]bool, len(fetched))
+	for _, c := range fetched {
+		k := keyOf(c)
+		fetchedKeys[k] = true
+		if entry := changelogEntry(c); storedEntries[k] != entry {
+			output = append(output, FieldChange{Field: ChangelogField, Stored: storedEntries[k], Fetched: entry})
+		}
+	}
+
+	for _, c := range stored {
+		if !fetchedKeys[keyOf(c)] {
+			output = append(output, FieldChange{Field: ChangelogField, Stored: changelogEntry(c)})
+		}
+	}
+
+	return output
+}
+
+func changelogEntry(c issue.Changelog) string {
+	field := c.Field
+	if c.IsStatus() {
+		field = issue.FieldStatus
+	}
+
+	return fmt.Sprintf("%d %s: %s -> %s", c.ID, field, c.From, c.To)
+}
+
+func sprintName(s *issue.Sprint) string {
+	if s == nil {
+		return ""
+	}
+
+	return fmt.Sprintf("%s (%d)", s.Name, s.ID)
+}
+
+func storyPoints(points *uint) string {
+	if points == nil {
+		return ""
+	}
+
+	return strconv.FormatUint(uint64(*points), 10)
+}
+
+func labels(l []issue.Label) string {
+	sorted := make([]string, len(l), len(l))
+	for i, label := range l {
+		sorted[i] = string(label)
+	}
+
+	slices.Sort(sorted)
+	return strings.Join(sorted, ",")
+}
+
+func parentKey(parent *issue.Issue) string {
+	if parent == nil {
+		return ""
+	}
+
+	return parent.Key
+}
@@ -0,0 +1,147 @@
+package usecase
+
+import (
+	"context"
+	"jira-integration/pkg/issue"
+	"reflect"
+	"testing"
+)
+
+type (
+	memoryStoredIssueDatabase map[string]issue.Issue
+)
+
+func (m memoryStoredIssueDatabase) GetStatuses(context.Context) (issue.Statuses, error) {
+	return nil, nil
+}
+
+func (m memoryStoredIssueDatabase) GetIssueByKey(_ context.Context, key string) (issue.Issue, bool, error) {
+	i, exists := m[key]
+	return i, exists, nil
+}
+
+func TestDiffIssues(t *testing.T) {
+	three, five := uint(3), uint(5)
+	stored := issue.Issue{
+		Stamp:       issue.Stamp{ID: 1, Key: "PAY-1"},
+		Status:      "In Progress",
+		Sprint:      &issue.Sprint{ID: 37, Name: "PAY 37"},
+		StoryPoints: &three,
+		Labels:      []issue.Label{"pix", "backend"},
+		Parent:      &issue.Issue{Stamp: issue.Stamp{Key: "PAY-0"}},
+		Changelog: []issue.Changelog{
+			{ID: 100, Field: issue.FieldStatus, From: "To Do", To: "In Progress"},
+			{ID: 101, Field: issue.FieldSprint, From: "PAY 36", To: "PAY 37"},
+		},
+	}
+
+	tests := []struct {
+		name   string
+		modify func(i *issue.Issue)
+		want   []FieldChange
+	}{
+		{
+			name:   "report nothing for identical issues",
+			modify: func(*issue.Issue) {},
+		},
+		{
+			name:   "compare the status",
+			modify: func(i *issue.Issue) { i.Status = "Done" },
+			want:   []FieldChange{{Field: StatusField, Stored: "In Progress", Fetched: "Done"}},
+		},
+		{
+			name:   "compare the sprint",
+			modify: func(i *issue.Issue) { i.Sprint = &issue.Sprint{ID: 38, Name: "PAY 38"} },
+			want:   []FieldChange{{Field: SprintField, Stored: "PAY 37 (37)", Fetched: "PAY 38 (38)"}},
+		},
+		{
+			name:   "compare the story points",
+			modify: func(i *issue.Issue) { i.StoryPoints = &five },
+			want:   []FieldChange{{Field: StoryPointsField, Stored: "3", Fetched: "5"}},
+		},
+		{
+			name:   "compare the labels regardless of their order",
+			modify: func(i *issue.Issue) { i.Labels = []issue.Label{"backend", "pix"} },
+		},
+		{
+			name:   "compare the labels",
+			modify: func(i *issue.Issue) { i.Labels = []issue.Label{"pix"} },
+			want:   []FieldChange{{Field: LabelsField, Stored: "backend,pix", Fetched: "pix"}},
+		},
+		{
+			name:   "compare the parent",
+			modify: func(i *issue.Issue) { i.Parent = nil },
+			want:   []FieldChange{{Field: ParentField, Stored: "PAY-0"}},
+		},
+		{
+			name: "compare the changelog values",
+			modify: func(i *issue.Issue) {
+				i.Changelog = []issue.Changelog{
+					{ID: 100, Field: issue.FieldStatus, From: "To Do", To: "Doing"},
+					{ID: 101, Field: issue.FieldSprint, From: "PAY 36", To: "PAY 37"},
+				}
+			},
+			want: []FieldChange{{Field: ChangelogField, Stored: "100 status: To Do -> In Progress", Fetched: "100 status: To Do -> Doing"}},
+		},
+		{
+			name: "compare the changelog entries",
+			modify: func(i *issue.Issue) {
+				i.Changelog = []issue.Changelog{
+					{ID: 100, Field: issue.FieldStatus, From: "To Do", To: "In Progress"},
+					{ID: 102, Field: issue.FieldStoryPoints, From: "3", To: "5"},
+				}
+			},
+			want: []FieldChange{
+				{Field: ChangelogField, Fetched: "102 story_points: 3 -> 5"},
+				{Field: ChangelogField, Stored: "101 sprint: PAY 36 -> PAY 37"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fetched := stored
+			tt.modify(&fetched)
+			if got := DiffIssues(stored, fetched); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DiffIssues() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiffDatabase(t *testing.T) {
+	db := memoryStoredIssueDatabase{
+		"PAY-1": {Stamp: issue.Stamp{ID: 1, Key: "PAY-1"}, Status: "Done"},
+		"PAY-2": {Stamp: issue.Stamp{ID: 2, Key: "PAY-2"}, Status: "To Do"},
+	}
+
+	var summary DiffSummary
+	var diffs []IssueDiff
+	diffDatabase := NewDiffDatabase(db, func(_ context.Context, diff IssueDiff) error {
+		summary.Add(diff)
+		diffs = append(diffs, diff)
+		return nil
+	})
+
+	if _, exists, _ := diffDatabase.GetByID(context.Background(), 1); exists {
+		t.Errorf("GetByID() exists, want every issue compared")
+	}
+
+	for _, i := range []issue.Issue{
+		{Stamp: issue.Stamp{ID: 1, Key: "PAY-1"}, Status: "Done"},
+		{Stamp: issue.Stamp{ID: 2, Key: "PAY-2"}, Status: "In Progress"},
+		{Stamp: issue.Stamp{ID: 3, Key: "PAY-3"}, Status: "To Do"},
+	} {
+		if err := diffDatabase.UpdateIssue(context.Background(), i); err != nil {
+			t.Fatalf("UpdateIssue() error = %v", err)
+		}
+	}
+
+	want := DiffSummary{Compared: 3, New: 1, Changed: 1}
+	if summary != want || summary.Unchanged() != 1 {
+		t.Errorf("summary = %+v with %d unchanged, want %+v with 1 unchanged", summary, summary.Unchanged(), want)
+	}
+
+	if !diffs[2].New || len(diffs[2].Changes) != 0 || diffs[0].Changed() {
+		t.Errorf("diffs = %+v, want PAY-1 unchanged and PAY-3 new", diffs)
+	}
+}
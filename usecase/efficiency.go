@@ -0,0 +1,43 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"jira-integration/pkg/report"
+	"time"
+)
+
+type (
+	FlowEfficiencyUseCase struct {
+		db  WorkItemDatabase
+		now func() time.Time
+	}
+)
+
+func NewFlowEfficiencyUseCase(db WorkItemDatabase) *FlowEfficiencyUseCase {
+	return &FlowEfficiencyUseCase{
+		db:  db,
+		now: time.Now,
+	}
+}
+
+// Execute aggregates the flow efficiency of the issues done in the last
+// weeks and, when asked, of the ones still in progress.
+func (uc FlowEfficiencyUseCase) Execute(ctx context.Context, weeks int, inProgress bool, by string) ([]report.FlowEfficiency, error) {
+	now := uc.now()
+	items, err := uc.db.ListDone(ctx, now.AddDate(0, 0, -7*weeks), now)
+	if err != nil {
+		return nil, fmt.Errorf("while listing done issues: %w", err)
+	}
+
+	if inProgress {
+		wip, err := uc.db.ListInProgress(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("while listing issues in progress: %w", err)
+		}
+
+		items = append(items, wip...)
+	}
+
+	return report.FlowEfficiencyBy(items, by), nil
+}
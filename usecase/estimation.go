@@ -0,0 +1,52 @@
+package usecase
+
+import (
+	"context"
+	"jira-integration/pkg/analytics"
+	"jira-integration/pkg/issue"
+	"jira-integration/pkg/report"
+	"time"
+)
+
+type (
+	EstimationUseCase struct {
+		iterator   IssueIterator
+		calculator *analytics.Calculator
+		now        func() time.Time
+	}
+)
+
+func NewEstimationUseCase(calculator *analytics.Calculator, iterator IssueIterator) *EstimationUseCase {
+	return &EstimationUseCase{
+		iterator:   iterator,
+		calculator: calculator,
+		now:        time.Now,
+	}
+}
+
+// Execute compares the estimates of the issues done in the last weeks with
+// their cycle time, replaying their changelog to count re-estimations.
+func (uc EstimationUseCase) Execute(ctx context.Context, weeks int) (report.EstimationReport, error) {
+	now := uc.now()
+	from := now.AddDate(0, 0, -7*weeks)
+	var samples []report.EstimationSample
+	err := uc.iterator.EachIssue(ctx, defaultBatchSize, func(ctx context.Context, issues []issue.Issue) error {
+		for _, i := range issues {
+			metrics := uc.calculator.Compute(i, now)
+			if metrics.DoneAt.Before(from) {
+				continue
+			}
+
+			if sample, ok := report.NewEstimationSample(i, metrics); ok {
+				samples = append(samples, sample)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return report.EstimationReport{}, err
+	}
+
+	return report.NewEstimationReport(samples), nil
+}
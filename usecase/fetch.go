@@ -4,6 +4,12 @@ import (
 	"context"
 	"fmt"
 	"jira-integration/pkg/issue"
+	"jira-integration/pkg/logging"
+	"jira-integration/pkg/tracing"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type (
@@ -14,37 +20,92 @@ type (
 
 	IssueDatabase interface {
 		StampDatabase
+		StatusCatalog
 		CreateIssue(ctx context.Context, i issue.Issue) error
 		UpdateIssue(ctx context.Context, i issue.Issue) error
 	}
 
+	// IssueHandler is called with every issue after it is persisted.
+	IssueHandler func(ctx context.Context, i issue.Issue) error
+
 	FetchUseCase struct {
-		client IssueClient
-		db     IssueDatabase
+		client     IssueClient
+		db         IssueDatabase
+		categories *statusCategories
+		handlers   []IssueHandler
 	}
 )
 
-func NewFetchUseCase(client IssueClient, db IssueDatabase) *FetchUseCase {
+func NewFetchUseCase(client IssueClient, db IssueDatabase, handlers ...IssueHandler) *FetchUseCase {
 	return &FetchUseCase{
-		client: client,
-		db:     db,
+		client:     client,
+		db:         db,
+		categories: &statusCategories{},
+		handlers:   handlers,
 	}
 }
 
-func (uc FetchUseCase) Execute(ctx context.Context, issueID uint) error {
-	fmt.Println("fetching", issueID)
+func (uc FetchUseCase) Execute(ctx context.Context, issueID uint) (err error) {
+	ctx, span := tracer.Start(ctx, "FetchUseCase.Execute", trace.WithAttributes(attribute.Int64("jira.issue.id", int64(issueID))))
+	defer tracing.End(span, &err)
+
+	start := time.Now()
+	logger := logging.FromContext(ctx).With("issue_id", issueID)
 	issueFromClient, err := uc.client.GetIssueByID(ctx, issueID)
 	if err != nil {
 		return fmt.Errorf("while fetching issue %d from streamer: %w", issueID, err)
 	}
 
-	changelog, _, err := uc.client.GetIssueChangelog(ctx, issueFromClient.Key, "")
+	span.SetAttributes(attribute.String("jira.issue.key", issueFromClient.Key))
+	logger = logger.With("issue_key", issueFromClient.Key)
+	ctx = logging.NewContext(ctx, logger)
+	changelog, err := uc.getChangelog(ctx, issueFromClient.Key)
 	if err != nil {
 		return fmt.Errorf("while fetching issue %d changelog: %w", issueID, err)
 	}
 
-	issueFromClient.Changelog = changelog
+	categories, err := uc.categories.get(ctx, uc.db)
+	if err != nil {
+		return fmt.Errorf("while loading status categories: %w", err)
+	}
+
+	issueFromClient.Changelog = setCategories(changelog, categories, issueFromClient)
+
+	if err := uc.save(ctx, issueID, issueFromClient); err != nil {
+		return err
+	}
+
+	for _, handler := range uc.handlers {
+		if err := handler(ctx, issueFromClient); err != nil {
+			return err
+		}
+	}
+
+	logger.Info("fetched issue", "changelog", len(changelog), "duration", time.Since(start))
+	return nil
+}
+
+func (uc FetchUseCase) getChangelog(ctx context.Context, issueKey string) ([]issue.Changelog, error) {
+	var output []issue.Changelog
+	nextPageToken := ""
+	for page := 1; ; page++ {
+		start := time.Now()
+		changelog, token, err := uc.client.GetIssueChangelog(ctx, issueKey, nextPageToken)
+		if err != nil {
+			return nil, err
+		}
 
+		logging.FromContext(ctx).Debug("fetched changelog page", "page", page, "histories", len(changelog), "duration", time.Since(start))
+		output = append(output, changelog...)
+		if token == "" || token == nextPageToken {
+			return output, nil
+		}
+
+		nextPageToken = token
+	}
+}
+
+func (uc FetchUseCase) save(ctx context.Context, issueID uint, issueFromClient issue.Issue) error {
 	if _, exist, err := uc.db.GetByID(ctx, issueID); err != nil {
 		return fmt.Errorf("while checking if issue %d exists: %w", issueID, err)
 	} else if exist {
@@ -69,3 +130,30 @@ func (uc FetchUseCase) updateIssue(ctx context.Context, issueFromClient issue.Is
 
 	return nil
 }
+
+// setCategories resolves the status category of each transition from the
+// catalog, falling back to the issue's current status when it isn't synced yet.
+func setCategories(changelog []issue.Changelog, categories map[string]string, i issue.Issue) []issue.Changelog {
+	lookup := func(statusID string) string {
+		if category, ok := categories[statusID]; ok {
+			return category
+		}
+
+		if statusID == i.StatusID {
+			return i.StatusCategory
+		}
+
+		return ""
+	}
+
+	for index, c := range changelog {
+		if !c.IsStatus() {
+			continue
+		}
+
+		changelog[index].FromCategory = lookup(c.FromID)
+		changelog[index].ToCategory = lookup(c.ToID)
+	}
+
+	return changelog
+}
@@ -0,0 +1,38 @@
+package usecase
+
+import (
+	"context"
+	"jira-integration/pkg/issue"
+	"jira-integration/pkg/report"
+	"time"
+)
+
+type (
+	CumulativeFlowUseCase struct {
+		iterator IssueIterator
+	}
+)
+
+func NewCumulativeFlowUseCase(iterator IssueIterator) *CumulativeFlowUseCase {
+	return &CumulativeFlowUseCase{
+		iterator: iterator,
+	}
+}
+
+// Execute replays the changelog of every issue in scope to count them per
+// status, or status category, at the end of each day between from and to.
+func (uc CumulativeFlowUseCase) Execute(ctx context.Context, from, to time.Time, by string) (report.CumulativeFlow, error) {
+	builder := report.NewCumulativeFlowBuilder(from, to, by)
+	err := uc.iterator.EachIssue(ctx, defaultBatchSize, func(ctx context.Context, issues []issue.Issue) error {
+		for _, i := range issues {
+			builder.Add(i)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return report.CumulativeFlow{}, err
+	}
+
+	return builder.Build(), nil
+}
@@ -0,0 +1,109 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"jira-integration/pkg/forecast"
+	"time"
+)
+
+const (
+	defaultForecastWeeks  = 12
+	defaultForecastTrials = 10000
+)
+
+var (
+	ParentNotFoundErr = errors.New("parent issue not found")
+)
+
+type (
+	ThroughputDatabase interface {
+		ListDoneDates(ctx context.Context, from, to time.Time) ([]time.Time, error)
+	}
+
+	ChildrenDatabase interface {
+		CountOpenChildren(ctx context.Context, parentKey string) (int, bool, error)
+	}
+
+	// ForecastRequest asks either how many items will be done until a date
+	// or when the remaining items, given or counted under a parent issue,
+	// will be done. Weeks is how much throughput history is sampled.
+	ForecastRequest struct {
+		Until       time.Time
+		Remaining   int
+		Parent      string
+		Weeks       int
+		Trials      int
+		Seed        uint64
+		Percentiles []int
+	}
+
+	ForecastUseCase struct {
+		throughput ThroughputDatabase
+		children   ChildrenDatabase
+		now        func() time.Time
+	}
+)
+
+func NewForecastUseCase(throughput ThroughputDatabase, children ChildrenDatabase) *ForecastUseCase {
+	return &ForecastUseCase{
+		throughput: throughput,
+		children:   children,
+		now:        time.Now,
+	}
+}
+
+func (uc ForecastUseCase) Execute(ctx context.Context, request ForecastRequest) (forecast.Forecast, error) {
+	now := uc.now()
+	weeks := request.Weeks
+	if weeks <= 0 {
+		weeks = defaultForecastWeeks
+	}
+
+	trials := request.Trials
+	if trials <= 0 {
+		trials = defaultForecastTrials
+	}
+
+	percentiles := request.Percentiles
+	if len(percentiles) == 0 {
+		percentiles = forecast.DefaultPercentiles
+	}
+
+	seed := request.Seed
+	if seed == 0 {
+		seed = uint64(now.UnixNano())
+	}
+
+	from := now.AddDate(0, 0, -7*weeks)
+	done, err := uc.throughput.ListDoneDates(ctx, from, now)
+	if err != nil {
+		return forecast.Forecast{}, fmt.Errorf("while listing done dates: %w", err)
+	}
+
+	simulator, err := forecast.NewSimulator(forecast.WeeklyThroughput(done, from, weeks), trials, seed)
+	if err != nil {
+		return forecast.Forecast{}, err
+	}
+
+	if !request.Until.IsZero() {
+		return simulator.HowMany(now, request.Until, percentiles), nil
+	}
+
+	remaining := request.Remaining
+	if request.Parent != "" {
+		count, exists, err := uc.children.CountOpenChildren(ctx, request.Parent)
+		if err != nil {
+			return forecast.Forecast{}, fmt.Errorf("while counting children of %s: %w", request.Parent, err)
+		}
+
+		if !exists {
+			return forecast.Forecast{}, fmt.Errorf("%w: %s", ParentNotFoundErr, request.Parent)
+		}
+
+		remaining = count
+	}
+
+	return simulator.When(now, remaining, percentiles), nil
+}
@@ -0,0 +1,76 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"jira-integration/pkg/analytics"
+	"jira-integration/pkg/issue"
+	"jira-integration/pkg/logging"
+	"time"
+)
+
+const (
+	defaultBatchSize = 500
+)
+
+type (
+	MetricsDatabase interface {
+		SaveMetrics(ctx context.Context, m analytics.Metrics) error
+	}
+
+	IssueIterator interface {
+		EachIssue(ctx context.Context, batchSize int, fn func(ctx context.Context, issues []issue.Issue) error) error
+	}
+
+	RefreshMetricsUseCase struct {
+		calculator *analytics.Calculator
+		db         MetricsDatabase
+		now        func() time.Time
+	}
+
+	BackfillMetricsUseCase struct {
+		refresh  *RefreshMetricsUseCase
+		iterator IssueIterator
+	}
+)
+
+func NewRefreshMetricsUseCase(calculator *analytics.Calculator, db MetricsDatabase) *RefreshMetricsUseCase {
+	return &RefreshMetricsUseCase{
+		calculator: calculator,
+		db:         db,
+		now:        time.Now,
+	}
+}
+
+func (uc RefreshMetricsUseCase) Execute(ctx context.Context, i issue.Issue) error {
+	metrics := uc.calculator.Compute(i, uc.now())
+	if err := uc.db.SaveMetrics(ctx, metrics); err != nil {
+		return fmt.Errorf("while saving metrics of issue %s: %w", i.Key, err)
+	}
+
+	return nil
+}
+
+func NewBackfillMetricsUseCase(refresh *RefreshMetricsUseCase, iterator IssueIterator) *BackfillMetricsUseCase {
+	return &BackfillMetricsUseCase{
+		refresh:  refresh,
+		iterator: iterator,
+	}
+}
+
+func (uc BackfillMetricsUseCase) Execute(ctx context.Context) error {
+	var count int
+	err := uc.iterator.EachIssue(ctx, defaultBatchSize, func(ctx context.Context, issues []issue.Issue) error {
+		for _, i := range issues {
+			if err := uc.refresh.Execute(ctx, i); err != nil {
+				return err
+			}
+		}
+
+		count += len(issues)
+		logging.FromContext(ctx).Info("refreshed metrics", "issues", count)
+		return nil
+	})
+
+	return err
+}
@@ -0,0 +1,99 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"jira-integration/pkg/issue"
+)
+
+type (
+	ArchivedIDs interface {
+		EachIssueID(ctx context.Context, batchSize int, fn func(ctx context.Context, issueIDs []uint) error) error
+		EachSprintID(ctx context.Context, batchSize int, fn func(ctx context.Context, sprintIDs []uint) error) error
+	}
+
+	StoredSprintDatabase interface {
+		GetSprint(ctx context.Context, sprintID uint) (issue.Sprint, bool, error)
+		SaveSprint(ctx context.Context, s issue.Sprint) error
+	}
+
+	// ReprocessUseCase rebuilds the stored sprints and issues from archived
+	// Jira responses. The publisher is expected to read from the archive too,
+	// so Jira is never called.
+	ReprocessUseCase struct {
+		archive   ArchivedIDs
+		sprints   SprintClient
+		db        StoredSprintDatabase
+		publisher IssuePublisher
+	}
+
+	ReprocessResult struct {
+		Sprints int
+		Issues  int
+	}
+)
+
+func NewReprocessUseCase(archive ArchivedIDs, sprints SprintClient, db StoredSprintDatabase, publisher IssuePublisher) *ReprocessUseCase {
+	return &ReprocessUseCase{
+		archive:   archive,
+		sprints:   sprints,
+		db:        db,
+		publisher: publisher,
+	}
+}
+
+func (uc ReprocessUseCase) Execute(ctx context.Context) (ReprocessResult, error) {
+	var output ReprocessResult
+	err := uc.archive.EachSprintID(ctx, defaultBatchSize, func(ctx context.Context, sprintIDs []uint) error {
+		for _, sprintID := range sprintIDs {
+			if err := uc.reprocessSprint(ctx, sprintID); err != nil {
+				return err
+			}
+
+			output.Sprints++
+		}
+
+		return nil
+	})
+	if err != nil {
+		return output, err
+	}
+
+	err = uc.archive.EachIssueID(ctx, defaultBatchSize, func(ctx context.Context, issueIDs []uint) error {
+		for _, issueID := range issueIDs {
+			if err := uc.publisher(ctx, issueID); err != nil {
+				return fmt.Errorf("while reprocessing issue %d: %w", issueID, err)
+			}
+
+			output.Issues++
+		}
+
+		return nil
+	})
+
+	return output, err
+}
+
+// reprocessSprint keeps the stored board name, which the sprint payload
+// doesn't have, as syncing sprints does.
+func (uc ReprocessUseCase) reprocessSprint(ctx context.Context, sprintID uint) error {
+	sprint, err := uc.sprints.GetSprint(ctx, sprintID)
+	if err != nil {
+		return fmt.Errorf("while reprocessing sprint %d: %w", sprintID, err)
+	}
+
+	stored, exists, err := uc.db.GetSprint(ctx, sprintID)
+	if err != nil {
+		return fmt.Errorf("while loading sprint %d: %w", sprintID, err)
+	}
+
+	if exists && sprint.BoardName == "" && sprint.BoardID == stored.BoardID {
+		sprint.BoardName = stored.BoardName
+	}
+
+	if err := uc.db.SaveSprint(ctx, *sprint); err != nil {
+		return fmt.Errorf("while saving sprint %d: %w", sprintID, err)
+	}
+
+	return nil
+}
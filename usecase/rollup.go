@@ -0,0 +1,38 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"jira-integration/pkg/analytics"
+	"jira-integration/pkg/report"
+	"time"
+)
+
+type (
+	WorkItemLister interface {
+		ListWorkItems(ctx context.Context) ([]analytics.WorkItem, error)
+	}
+
+	RollupUseCase struct {
+		db      WorkItemLister
+		builder *report.RollupBuilder
+		now     func() time.Time
+	}
+)
+
+func NewRollupUseCase(builder *report.RollupBuilder, db WorkItemLister) *RollupUseCase {
+	return &RollupUseCase{
+		db:      db,
+		builder: builder,
+		now:     time.Now,
+	}
+}
+
+func (uc RollupUseCase) Execute(ctx context.Context) ([]report.Rollup, error) {
+	items, err := uc.db.ListWorkItems(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("while listing issues: %w", err)
+	}
+
+	return uc.builder.Build(items, uc.now()), nil
+}
@@ -0,0 +1,41 @@
+package usecase
+
+import (
+	"context"
+	"jira-integration/pkg/issue"
+)
+
+type (
+	// SearchIssuesUseCase resolves a JQL query to the ids of the matching
+	// issues, so reports can be scoped by JQL over the stored data.
+	SearchIssuesUseCase struct {
+		streamer IssueStreamer
+	}
+)
+
+func NewSearchIssuesUseCase(streamer IssueStreamer) *SearchIssuesUseCase {
+	return &SearchIssuesUseCase{
+		streamer: streamer,
+	}
+}
+
+func (uc SearchIssuesUseCase) Execute(ctx context.Context, jql string) ([]uint, error) {
+	var output []uint
+	var token string
+	for {
+		var stamps []issue.Stamp
+		var err error
+		stamps, token, err = uc.streamer.SearchIssuesByJQL(ctx, jql, token)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, stamp := range stamps {
+			output = append(output, stamp.ID)
+		}
+
+		if token == "" {
+			return output, nil
+		}
+	}
+}
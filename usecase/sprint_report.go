@@ -0,0 +1,108 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"jira-integration/pkg/issue"
+	"jira-integration/pkg/report"
+	"time"
+)
+
+var (
+	SprintNotFoundErr = errors.New("sprint not found")
+)
+
+type (
+	SprintReportDatabase interface {
+		GetSprint(ctx context.Context, sprintID uint) (issue.Sprint, bool, error)
+		ListBoardSprints(ctx context.Context, boardID uint, states []string, limit int) ([]issue.Sprint, error)
+		ListSprintIssues(ctx context.Context, sprintID uint) ([]issue.Issue, error)
+	}
+
+	// SprintReportRequest asks either for a single sprint or for the last
+	// closed sprints of a board. Window is how many sprints are averaged in
+	// the rolling velocity.
+	SprintReportRequest struct {
+		SprintID uint
+		BoardID  uint
+		Last     int
+		Window   int
+	}
+
+	SprintReportUseCase struct {
+		db       SprintReportDatabase
+		reporter *report.SprintReporter
+		now      func() time.Time
+	}
+)
+
+func NewSprintReportUseCase(reporter *report.SprintReporter, db SprintReportDatabase) *SprintReportUseCase {
+	return &SprintReportUseCase{
+		db:       db,
+		reporter: reporter,
+		now:      time.Now,
+	}
+}
+
+func (uc SprintReportUseCase) Execute(ctx context.Context, request SprintReportRequest) ([]report.SprintReport, error) {
+	window := max(request.Window, 1)
+	sprints, last, err := uc.sprints(ctx, request, window)
+	if err != nil {
+		return nil, err
+	}
+
+	reports := make([]report.SprintReport, len(sprints), len(sprints))
+	for i, s := range sprints {
+		issues, err := uc.db.ListSprintIssues(ctx, s.ID)
+		if err != nil {
+			return nil, fmt.Errorf("while listing issues of sprint %d: %w", s.ID, err)
+		}
+
+		reports[i] = uc.reporter.Build(s, issues, uc.now())
+	}
+
+	reports = report.RollVelocity(reports, window)
+	return reports[max(0, len(reports)-last):], nil
+}
+
+// sprints returns the requested sprints plus the previous ones needed by the
+// rolling velocity, and how many of them were actually requested.
+func (uc SprintReportUseCase) sprints(ctx context.Context, request SprintReportRequest, window int) ([]issue.Sprint, int, error) {
+	if request.SprintID == 0 {
+		last := max(request.Last, 1)
+		sprints, err := uc.db.ListBoardSprints(ctx, request.BoardID, []string{"closed"}, last+window-1)
+		return sprints, last, err
+	}
+
+	s, exists, err := uc.db.GetSprint(ctx, request.SprintID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if !exists {
+		return nil, 0, fmt.Errorf("%w: %d", SprintNotFoundErr, request.SprintID)
+	}
+
+	sprints := []issue.Sprint{s}
+	if s.BoardID == 0 || window == 1 {
+		return sprints, 1, nil
+	}
+
+	closed, err := uc.db.ListBoardSprints(ctx, s.BoardID, []string{"closed"}, 0)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	for _, previous := range closed {
+		if len(sprints) == window {
+			break
+		}
+
+		if previous.ID != s.ID && previous.StartedAt.Before(s.StartedAt) {
+			sprints = append(sprints, previous)
+		}
+	}
+
+	return sprints, 1, nil
+}
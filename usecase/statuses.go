@@ -0,0 +1,80 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"jira-integration/pkg/issue"
+	"jira-integration/pkg/logging"
+	"sync"
+)
+
+type (
+	StatusClient interface {
+		GetStatuses(ctx context.Context) (issue.Statuses, error)
+	}
+
+	StatusDatabase interface {
+		SaveStatus(ctx context.Context, s issue.Status) error
+		UpdateChangelogCategories(ctx context.Context) error
+	}
+
+	StatusCatalog interface {
+		GetStatuses(ctx context.Context) (issue.Statuses, error)
+	}
+
+	SyncStatusesUseCase struct {
+		db     StatusDatabase
+		client StatusClient
+	}
+
+	statusCategories struct {
+		lock       sync.Mutex
+		categories map[string]string
+	}
+)
+
+func NewSyncStatusesUseCase(client StatusClient, db StatusDatabase) *SyncStatusesUseCase {
+	return &SyncStatusesUseCase{
+		client: client,
+		db:     db,
+	}
+}
+
+func (uc SyncStatusesUseCase) Execute(ctx context.Context) error {
+	statuses, err := uc.client.GetStatuses(ctx)
+	if err != nil {
+		return fmt.Errorf("while fetching statuses: %w", err)
+	}
+
+	logging.FromContext(ctx).Info("syncing statuses", "statuses", len(statuses))
+	for _, s := range statuses {
+		if err := uc.db.SaveStatus(ctx, s); err != nil {
+			return fmt.Errorf("while saving status %s: %w", s.Name, err)
+		}
+	}
+
+	if err := uc.db.UpdateChangelogCategories(ctx); err != nil {
+		return fmt.Errorf("while updating changelog categories: %w", err)
+	}
+
+	return nil
+}
+
+// get loads the catalog once, so a fetch run doesn't query it for every issue.
+// A failed load isn't kept, so the next issue tries again.
+func (s *statusCategories) get(ctx context.Context, catalog StatusCatalog) (map[string]string, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.categories != nil {
+		return s.categories, nil
+	}
+
+	statuses, err := catalog.GetStatuses(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	s.categories = statuses.Categories()
+	return s.categories, nil
+}
@@ -0,0 +1,99 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"jira-integration/pkg/issue"
+	"reflect"
+	"testing"
+)
+
+type (
+	flakyStatusCatalog struct {
+		failures int
+		calls    int
+		statuses issue.Statuses
+	}
+)
+
+func (f *flakyStatusCatalog) GetStatuses(context.Context) (issue.Statuses, error) {
+	f.calls++
+	if f.calls <= f.failures {
+		return nil, errors.New("connection reset")
+	}
+
+	return f.statuses, nil
+}
+
+func TestStatusCategories_get(t *testing.T) {
+	catalog := &flakyStatusCatalog{
+		failures: 1,
+		statuses: issue.Statuses{{ID: "3", Name: "In Progress", CategoryKey: "indeterminate"}},
+	}
+
+	categories := &statusCategories{}
+	if _, err := categories.get(context.Background(), catalog); err == nil {
+		t.Fatalf("get() error = nil, want the catalog error")
+	}
+
+	want := map[string]string{"3": "indeterminate"}
+	for range 2 {
+		got, err := categories.get(context.Background(), catalog)
+		if err != nil || !reflect.DeepEqual(got, want) {
+			t.Fatalf("get() = %v, %v, want %v", got, err, want)
+		}
+	}
+
+	if catalog.calls != 2 {
+		t.Errorf("get() loaded the catalog %d times, want 2", catalog.calls)
+	}
+}
+
+func TestSetCategories(t *testing.T) {
+	categories := map[string]string{
+		"1":     "new",
+		"3":     "indeterminate",
+		"10002": "done",
+	}
+
+	tests := []struct {
+		name      string
+		changelog []issue.Changelog
+		i         issue.Issue
+		want      []issue.Changelog
+	}{
+		{
+			name:      "resolve known statuses from the catalog",
+			changelog: []issue.Changelog{{Field: issue.FieldStatus, From: "To Do", To: "In Progress", FromID: "1", ToID: "3"}},
+			want:      []issue.Changelog{{Field: issue.FieldStatus, From: "To Do", To: "In Progress", FromID: "1", ToID: "3", FromCategory: "new", ToCategory: "indeterminate"}},
+		},
+		{
+			name:      "resolve renamed statuses by their id",
+			changelog: []issue.Changelog{{From: "Doing", To: "Shipped", FromID: "3", ToID: "10002"}},
+			want:      []issue.Changelog{{From: "Doing", To: "Shipped", FromID: "3", ToID: "10002", FromCategory: "indeterminate", ToCategory: "done"}},
+		},
+		{
+			name:      "leave unknown statuses without category",
+			changelog: []issue.Changelog{{Field: issue.FieldStatus, From: "Triage", To: "To Do", FromID: "20000", ToID: "1"}},
+			want:      []issue.Changelog{{Field: issue.FieldStatus, From: "Triage", To: "To Do", FromID: "20000", ToID: "1", ToCategory: "new"}},
+		},
+		{
+			name:      "fall back to the issue status when it isn't synced yet",
+			changelog: []issue.Changelog{{Field: issue.FieldStatus, From: "To Do", To: "Review", FromID: "1", ToID: "20001"}},
+			i:         issue.Issue{StatusID: "20001", StatusCategory: "indeterminate"},
+			want:      []issue.Changelog{{Field: issue.FieldStatus, From: "To Do", To: "Review", FromID: "1", ToID: "20001", FromCategory: "new", ToCategory: "indeterminate"}},
+		},
+		{
+			name:      "skip other fields",
+			changelog: []issue.Changelog{{Field: issue.FieldSprint, From: "PAY 1", To: "PAY 2", FromID: "1", ToID: "3"}},
+			want:      []issue.Changelog{{Field: issue.FieldSprint, From: "PAY 1", To: "PAY 2", FromID: "1", ToID: "3"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := setCategories(tt.changelog, categories, tt.i); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("setCategories() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -4,8 +4,18 @@ package usecase
 
 import (
 	"context"
-	"fmt"
 	"jira-integration/pkg/issue"
+	"jira-integration/pkg/logging"
+	"jira-integration/pkg/tracing"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var (
+	tracer = otel.Tracer("jira-integration/usecase")
 )
 
 type (
@@ -15,6 +25,10 @@ type (
 
 	IssuePublisher func(ctx context.Context, issueID uint) error
 
+	// IssueObserver is told whether each streamed issue was fetched, skipped
+	// for being up to date, or failed.
+	IssueObserver func(ctx context.Context, stamp issue.Stamp, outcome string)
+
 	StampDatabase interface {
 		GetByID(ctx context.Context, issueID uint) (issue.Stamp, bool, error)
 	}
@@ -23,6 +37,8 @@ type (
 		streamer  IssueStreamer
 		publisher IssuePublisher
 		database  StampDatabase
+		observer  IssueObserver
+		force     bool
 	}
 )
 
@@ -34,16 +50,39 @@ func NewStreamUseCase(streamer IssueStreamer, publisher IssuePublisher, database
 	}
 }
 
-func (c StreamUseCase) Execute(ctx context.Context, jql string) error {
+// WithObserver returns a copy of the use case that tells the observer the
+// outcome of every issue.
+func (c StreamUseCase) WithObserver(observer IssueObserver) *StreamUseCase {
+	c.observer = observer
+	return &c
+}
+
+// WithForce returns a copy of the use case that fetches the stored issues even
+// when they are up to date, backfilling the fields added after they were
+// stored, such as the sprint, story points and flag history.
+func (c StreamUseCase) WithForce(force bool) *StreamUseCase {
+	c.force = force
+	return &c
+}
+
+func (c StreamUseCase) Execute(ctx context.Context, jql string) (err error) {
+	ctx, span := tracer.Start(ctx, "StreamUseCase.Execute", trace.WithAttributes(attribute.String("jira.jql", jql)))
+	defer tracing.End(span, &err)
+
 	issues := make(chan issue.Stamp)
 	errs := make(chan error, 1)
 
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
+	logger := logging.FromContext(ctx).With("jql", jql)
+	ctx = logging.NewContext(ctx, logger)
+	start := time.Now()
+	var fetched, skipped int
+
 	go func() {
 		defer close(issues)
-		if err := c.search(ctx, jql, "", issues); err != nil {
+		if err := c.search(ctx, jql, "", 1, issues); err != nil {
 			errs <- err
 			cancel()
 		}
@@ -57,46 +96,85 @@ func (c StreamUseCase) Execute(ctx context.Context, jql string) error {
 			return err
 		case i, ok := <-issues:
 			if !ok {
+				span.SetAttributes(attribute.Int("issues.fetched", fetched), attribute.Int("issues.skipped", skipped))
+				logger.Info("streamed issues", "fetched", fetched, "skipped", skipped, "duration", time.Since(start))
 				return nil
 			}
 
-			stamp, exists, err := c.database.GetByID(ctx, i.ID)
+			upToDate, err := c.upToDate(ctx, i)
 			if err != nil {
 				cancel()
 				return err
 			}
 
-			if exists && stamp.UpdatedAt.Equal(i.UpdatedAt) {
-				fmt.Println("skipping", i.ID)
+			if upToDate {
+				logger.Debug("skipping issue", "issue_id", i.ID, "issue_key", i.Key)
+				c.observe(ctx, i, issue.OutcomeSkipped)
+				skipped++
 				continue
 			}
 
 			if err := c.publisher(ctx, i.ID); err != nil {
+				c.observe(ctx, i, issue.OutcomeFailed)
 				cancel()
 				return err
 			}
+
+			c.observe(ctx, i, issue.OutcomeFetched)
+			fetched++
 		}
 	}
 }
 
-func (c StreamUseCase) search(ctx context.Context, jql, nextPageToken string, issues chan issue.Stamp) error {
+// searchPage traces each page apart from the time spent handing its issues
+// over, which is spent by the publisher.
+func (c StreamUseCase) searchPage(ctx context.Context, jql, nextPageToken string, page int) (_ []issue.Stamp, _ string, err error) {
+	ctx, span := tracer.Start(ctx, "StreamUseCase.searchPage", trace.WithAttributes(attribute.Int("jira.page", page)))
+	defer tracing.End(span, &err)
+
+	return c.streamer.SearchIssuesByJQL(ctx, jql, nextPageToken)
+}
+
+func (c StreamUseCase) upToDate(ctx context.Context, i issue.Stamp) (bool, error) {
+	if c.force {
+		return false, nil
+	}
+
+	stamp, exists, err := c.database.GetByID(ctx, i.ID)
+	if err != nil {
+		return false, err
+	}
+
+	return exists && stamp.UpdatedAt.Equal(i.UpdatedAt), nil
+}
+
+func (c StreamUseCase) observe(ctx context.Context, stamp issue.Stamp, outcome string) {
+	if c.observer != nil {
+		c.observer(ctx, stamp, outcome)
+	}
+}
+
+func (c StreamUseCase) search(ctx context.Context, jql, nextPageToken string, page int, issues chan issue.Stamp) error {
 	select {
 	case <-ctx.Done():
 		return ctx.Err()
 	default:
 	}
 
-	response, token, err := c.streamer.SearchIssuesByJQL(ctx, jql, nextPageToken)
+	start := time.Now()
+	response, token, err := c.searchPage(ctx, jql, nextPageToken, page)
 	if err != nil {
 		return err
 	}
 
+	logging.FromContext(ctx).Debug("searched issues page", "page", page, "issues", len(response), "duration", time.Since(start))
+
 	for _, r := range response {
 		issues <- r
 	}
 
 	if token != "" {
-		return c.search(ctx, jql, token, issues)
+		return c.search(ctx, jql, token, page+1, issues)
 	}
 
 	return nil
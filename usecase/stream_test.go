@@ -0,0 +1,86 @@
+package usecase
+
+import (
+	"context"
+	"jira-integration/pkg/issue"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+)
+
+type (
+	stubIssueStreamer struct {
+		pages [][]issue.Stamp
+	}
+
+	memoryStampDatabase map[uint]issue.Stamp
+)
+
+func (s stubIssueStreamer) SearchIssuesByJQL(_ context.Context, _, nextPageToken string) ([]issue.Stamp, string, error) {
+	page, _ := strconv.Atoi(nextPageToken)
+	if page+1 < len(s.pages) {
+		return s.pages[page], strconv.Itoa(page + 1), nil
+	}
+
+	return s.pages[page], "", nil
+}
+
+func (m memoryStampDatabase) GetByID(_ context.Context, issueID uint) (issue.Stamp, bool, error) {
+	stamp, exists := m[issueID]
+	return stamp, exists, nil
+}
+
+func TestStreamUseCase_Execute(t *testing.T) {
+	updatedAt := time.Date(2024, 3, 11, 12, 0, 0, 0, time.UTC)
+	streamer := stubIssueStreamer{
+		pages: [][]issue.Stamp{
+			{{ID: 1, Key: "PAY-1", UpdatedAt: updatedAt}, {ID: 2, Key: "PAY-2", UpdatedAt: updatedAt}},
+			{{ID: 3, Key: "PAY-3", UpdatedAt: updatedAt}},
+		},
+	}
+	db := memoryStampDatabase{
+		1: {ID: 1, Key: "PAY-1", UpdatedAt: updatedAt},
+		2: {ID: 2, Key: "PAY-2", UpdatedAt: updatedAt.Add(-time.Hour)},
+	}
+
+	tests := []struct {
+		name        string
+		force       bool
+		wantFetched []uint
+		wantSkipped []uint
+	}{
+		{
+			name:        "skip the issues stored with the same update time",
+			wantFetched: []uint{2, 3},
+			wantSkipped: []uint{1},
+		},
+		{
+			name:        "fetch every issue when forced",
+			force:       true,
+			wantFetched: []uint{1, 2, 3},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var fetched, skipped []uint
+			publisher := func(_ context.Context, issueID uint) error {
+				fetched = append(fetched, issueID)
+				return nil
+			}
+			observer := func(_ context.Context, stamp issue.Stamp, outcome string) {
+				if outcome == issue.OutcomeSkipped {
+					skipped = append(skipped, stamp.ID)
+				}
+			}
+
+			uc := NewStreamUseCase(streamer, publisher, db).WithObserver(observer).WithForce(tt.force)
+			if err := uc.Execute(context.Background(), "project = PAY"); err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+			if !reflect.DeepEqual(fetched, tt.wantFetched) || !reflect.DeepEqual(skipped, tt.wantSkipped) {
+				t.Errorf("Execute() fetched %v and skipped %v, want %v and %v", fetched, skipped, tt.wantFetched, tt.wantSkipped)
+			}
+		})
+	}
+}
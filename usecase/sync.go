@@ -2,8 +2,8 @@ package usecase
 
 import (
 	"context"
-	"fmt"
 	"jira-integration/pkg/issue"
+	"jira-integration/pkg/logging"
 )
 
 type (
@@ -41,7 +41,11 @@ func (uc SyncSprintsUseCase) Execute(ctx context.Context, states []string) error
 			return err
 		}
 
-		fmt.Println("syncing sprint", retrievedSprint.Name)
+		if retrievedSprint.BoardName == "" && retrievedSprint.BoardID == s.BoardID {
+			retrievedSprint.BoardName = s.BoardName
+		}
+
+		logging.FromContext(ctx).Info("syncing sprint", "sprint_id", retrievedSprint.ID, "sprint", retrievedSprint.Name, "state", retrievedSprint.State)
 
 		if err := uc.db.SaveSprint(ctx, *retrievedSprint); err != nil {
 			return err
@@ -0,0 +1,49 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"jira-integration/pkg/issue"
+	"jira-integration/pkg/logging"
+)
+
+type (
+	VersionClient interface {
+		GetProjectVersions(ctx context.Context, projectKeyOrID string) ([]issue.Version, error)
+	}
+
+	VersionDatabase interface {
+		SaveVersion(ctx context.Context, v issue.Version) error
+	}
+
+	SyncVersionsUseCase struct {
+		db     VersionDatabase
+		client VersionClient
+	}
+)
+
+func NewSyncVersionsUseCase(client VersionClient, db VersionDatabase) *SyncVersionsUseCase {
+	return &SyncVersionsUseCase{
+		client: client,
+		db:     db,
+	}
+}
+
+func (uc SyncVersionsUseCase) Execute(ctx context.Context, projects []string) error {
+	for _, project := range projects {
+		versions, err := uc.client.GetProjectVersions(ctx, project)
+		if err != nil {
+			return fmt.Errorf("while fetching versions of project %s: %w", project, err)
+		}
+
+		logging.FromContext(ctx).Info("syncing versions", "project", project, "versions", len(versions))
+
+		for _, v := range versions {
+			if err := uc.db.SaveVersion(ctx, v); err != nil {
+				return fmt.Errorf("while saving version %s: %w", v.Name, err)
+			}
+		}
+	}
+
+	return nil
+}